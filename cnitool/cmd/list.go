@@ -0,0 +1,212 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+var (
+	// Used for flags
+	listOutput  string
+	showSources bool
+)
+
+// networkEntry describes one configuration file found in NETCONFPATH
+type networkEntry struct {
+	Name       string   `json:"name,omitempty"`
+	File       string   `json:"file"`
+	CNIVersion string   `json:"cniVersion,omitempty"`
+	Plugins    []string `json:"plugins,omitempty"`
+	// Effective is true if libcni uses this file for the network's name
+	Effective bool `json:"effective"`
+	// ShadowedBy is the file libcni uses instead of this one
+	ShadowedBy string `json:"shadowedBy,omitempty"`
+	// Error is set when the file could not be loaded
+	Error string `json:"error,omitempty"`
+}
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List network configurations",
+	Long: `List the network configurations found in NETCONFPATH.
+For every configuration file this shows the network name, its CNI version and
+the plugin types in the order they are executed, including plugins loaded from
+<dir>/<network-name>/*.conf. When several files define the same network, the
+file libcni uses is marked as effective.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if listOutput != "text" && listOutput != "json" {
+			return fmt.Errorf("unknown output format %q", listOutput)
+		}
+
+		entries, err := loadNetworkEntries(netConfDir())
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if listOutput == "json" {
+			data, err := json.MarshalIndent(entries, "", "    ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(out, "%s\n", data)
+			return err
+		}
+		return printNetworkEntries(out, entries)
+	},
+}
+
+// showCmd represents the show command
+var showCmd = &cobra.Command{
+	Use:   "show <network-name>",
+	Short: "Show a resolved network configuration",
+	Long: `Show the configuration libcni uses for a network in NETCONFPATH.
+Legacy single-plugin configurations are converted to a list, and plugins
+loaded from <dir>/<network-name>/*.conf are merged into the list. With
+--sources, the file each plugin was loaded from is shown instead.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		loader := &libcni.ConfigLoader{PluginSources: true}
+		list, err := loader.LoadNetworkConf(netConfDir(), args[0])
+		if err != nil {
+			return err
+		}
+		if showSources {
+			return printPluginSources(cmd.OutOrStdout(), list)
+		}
+		data, err := resolvedConfBytes(list)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", data)
+		return err
+	},
+}
+
+func init() {
+	listCmd.Flags().StringVarP(&listOutput, "output", "o", "text", "Output format: text or json")
+	rootCmd.AddCommand(listCmd)
+	showCmd.Flags().BoolVar(&showSources, "sources", false, "Show the file each plugin was loaded from")
+	rootCmd.AddCommand(showCmd)
+}
+
+// netConfDir returns the directory to load network configurations from
+func netConfDir() string {
+	netdir := os.Getenv(EnvNetDir)
+	if netdir == "" {
+		netdir = DefaultNetDir
+	}
+	return netdir
+}
+
+// loadNetworkEntries describes every configuration file in dir
+func loadNetworkEntries(dir string) ([]*networkEntry, error) {
+	configs, err := libcni.LoadNetworkConfigs(dir)
+	if err != nil {
+		return nil, err
+	}
+	shadowedBy := shadowingFiles(configs)
+
+	entries := make([]*networkEntry, 0, len(configs.Files))
+	for _, f := range configs.Files {
+		entry := &networkEntry{
+			File:       f.File,
+			Effective:  f.Effective,
+			ShadowedBy: shadowedBy[f.File],
+		}
+		if f.Err != nil {
+			entry.Error = f.Err.Error()
+		} else {
+			entry.Name = f.Config.Name
+			entry.CNIVersion = f.Config.CNIVersion
+			for _, p := range f.Config.Plugins {
+				entry.Plugins = append(entry.Plugins, p.Network.Type)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// shadowingFiles maps every shadowed file to the file used instead
+func shadowingFiles(configs *libcni.NetworkConfigs) map[string]string {
+	shadowedBy := map[string]string{}
+	for _, c := range configs.Conflicts {
+		for _, file := range c.Shadowed {
+			shadowedBy[file] = c.Effective
+		}
+	}
+	return shadowedBy
+}
+
+func printNetworkEntries(out io.Writer, entries []*networkEntry) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tFILE\tCNIVERSION\tPLUGINS\tSTATUS")
+	for _, e := range entries {
+		status := "effective"
+		switch {
+		case e.Error != "":
+			status = "error: " + e.Error
+		case e.ShadowedBy != "":
+			status = "shadowed by " + e.ShadowedBy
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.Name, e.File, e.CNIVersion, strings.Join(e.Plugins, ","), status)
+	}
+	return w.Flush()
+}
+
+// resolvedConfBytes returns the list's configuration with every plugin,
+// including those loaded from separate files, in the "plugins" key.
+func resolvedConfBytes(list *libcni.NetworkConfigList) ([]byte, error) {
+	raw := map[string]interface{}{}
+	if err := json.Unmarshal(list.Bytes, &raw); err != nil {
+		return nil, err
+	}
+	plugins := make([]json.RawMessage, 0, len(list.Plugins))
+	for _, p := range list.Plugins {
+		plugins = append(plugins, p.Bytes)
+	}
+	raw["plugins"] = plugins
+	return json.MarshalIndent(raw, "", "    ")
+}
+
+// printPluginSources lists the list's plugins in execution order with the
+// file each was loaded from
+func printPluginSources(out io.Writer, list *libcni.NetworkConfigList) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "INDEX\tTYPE\tSOURCE")
+	for i, p := range list.Plugins {
+		source := ""
+		if i < len(list.PluginSources) {
+			source = list.PluginSources[i]
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", i, p.Network.Type, source)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+// Used for flags
+var pluginsOutput string
+
+// pluginEntry describes one plugin name found in CNI_PATH
+type pluginEntry struct {
+	Name       string            `json:"name"`
+	Candidates []*candidateEntry `json:"candidates,omitempty"`
+	// Error is set when the plugin could not be found
+	Error string `json:"error,omitempty"`
+}
+
+// candidateEntry describes one file that could provide a plugin
+type candidateEntry struct {
+	Path       string   `json:"path"`
+	DirIndex   int      `json:"dirIndex"`
+	Mode       string   `json:"mode"`
+	Executable bool     `json:"executable"`
+	Selected   bool     `json:"selected"`
+	Versions   []string `json:"supportedVersions,omitempty"`
+	// Error is set when the plugin's VERSION command failed
+	Error string `json:"error,omitempty"`
+}
+
+// pluginsCmd represents the plugins command
+var pluginsCmd = &cobra.Command{
+	Use:   "plugins [plugin-name...]",
+	Short: "List plugins in CNI_PATH",
+	Long: `List the plugins found in CNI_PATH with the CNI versions they support.
+Without arguments every executable in CNI_PATH is listed. When a plugin exists
+in several directories, the one executed is marked as selected and the others
+as shadowed.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if pluginsOutput != "text" && pluginsOutput != "json" {
+			return fmt.Errorf("unknown output format %q", pluginsOutput)
+		}
+
+		reports, err := getCNIConfig().PluginReports(context.TODO(), args...)
+		if err != nil {
+			return err
+		}
+		entries := make([]*pluginEntry, 0, len(reports))
+		for _, r := range reports {
+			entries = append(entries, newPluginEntry(r))
+		}
+
+		out := cmd.OutOrStdout()
+		if pluginsOutput == "json" {
+			data, err := json.MarshalIndent(entries, "", "    ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(out, "%s\n", data)
+			return err
+		}
+		return printPluginEntries(out, entries)
+	},
+}
+
+func init() {
+	pluginsCmd.Flags().StringVarP(&pluginsOutput, "output", "o", "text", "Output format: text or json")
+	rootCmd.AddCommand(pluginsCmd)
+}
+
+func newPluginEntry(r *libcni.PluginReport) *pluginEntry {
+	entry := &pluginEntry{Name: r.Name}
+	if r.Err != nil {
+		entry.Error = r.Err.Error()
+	}
+	for _, c := range r.Candidates {
+		ce := &candidateEntry{
+			Path:       c.Path,
+			DirIndex:   c.DirIndex,
+			Mode:       c.Mode.String(),
+			Executable: c.Executable,
+			Selected:   c.Selected,
+			Versions:   c.Versions,
+		}
+		if c.VersionErr != nil {
+			ce.Error = c.VersionErr.Error()
+		}
+		entry.Candidates = append(entry.Candidates, ce)
+	}
+	return entry
+}
+
+func printPluginEntries(out io.Writer, entries []*pluginEntry) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPATH\tVERSIONS\tSTATUS")
+	for _, e := range entries {
+		if e.Error != "" {
+			fmt.Fprintf(w, "%s\t\t\terror: %s\n", e.Name, e.Error)
+			continue
+		}
+		for _, c := range e.Candidates {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", e.Name, c.Path, strings.Join(c.Versions, ","), c.status())
+		}
+	}
+	return w.Flush()
+}
+
+func (c *candidateEntry) status() string {
+	status := []string{"shadowed"}
+	if c.Selected {
+		status[0] = "selected"
+	}
+	switch {
+	case !c.Executable:
+		status = append(status, "not executable")
+	case c.Error != "":
+		status = append(status, "error: "+c.Error)
+	}
+	return strings.Join(status, ", ")
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+var (
+	// Used for flags
+	validateOutput       string
+	validateCallPlugins  bool
+	validateStrict       bool
+	validateCapabilities []string
+)
+
+// validateReport is the outcome of validating one configuration file or
+// network
+type validateReport struct {
+	Name  string `json:"name,omitempty"`
+	File  string `json:"file,omitempty"`
+	Valid bool   `json:"valid"`
+	// Error is set when the configuration could not be loaded at all
+	Error string `json:"error,omitempty"`
+	// ShadowedBy is set when another file defines the same network
+	ShadowedBy string `json:"shadowedBy,omitempty"`
+
+	CNIVersions []string `json:"cniVersions,omitempty"`
+	*libcni.ValidationResult
+}
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate [network-name | file | directory]",
+	Short: "Validate network configurations",
+	Long: `Validate network configurations without executing them.
+The argument may name a network in NETCONFPATH, a configuration file or a
+directory of configuration files. Without an argument every configuration in
+NETCONFPATH is validated. Each configuration is checked against the
+configuration schema, its plugins are looked up in CNI_PATH and asked for the
+versions they support, and the highest version supported by all of them is
+selected. The command fails if any configuration has errors, or with --strict
+also if it has warnings.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if validateOutput != "text" && validateOutput != "json" {
+			return fmt.Errorf("unknown output format %q", validateOutput)
+		}
+
+		target := ""
+		if len(args) > 0 {
+			target = args[0]
+		}
+		reports, err := loadValidateReports(target)
+		if err != nil {
+			return err
+		}
+
+		cninet := getCNIConfig()
+		opts := &libcni.ValidateOptions{
+			CallPlugins:         validateCallPlugins,
+			CheckSchema:         true,
+			RuntimeCapabilities: validateCapabilities,
+			NegotiateVersion:    true,
+		}
+		failSeverity := libcni.SeverityError
+		if validateStrict {
+			failSeverity = libcni.SeverityWarning
+		}
+		failed := 0
+		for _, r := range reports {
+			if r.list != nil {
+				r.ValidationResult = cninet.ValidateNetworkListDetailed(context.TODO(), r.list, opts)
+				r.Valid = len(r.FindingsAtLeast(failSeverity)) == 0
+			}
+			if !r.Valid {
+				failed++
+			}
+		}
+
+		out := cmd.OutOrStdout()
+		if validateOutput == "json" {
+			if err := printValidateJSON(out, reports); err != nil {
+				return err
+			}
+		} else {
+			printValidateText(out, reports)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d network configurations are invalid", failed, len(reports))
+		}
+		return nil
+	},
+}
+
+func init() {
+	validateCmd.Flags().StringVarP(&validateOutput, "output", "o", "text", "Output format: text or json")
+	validateCmd.Flags().BoolVar(&validateCallPlugins, "call-plugins", false, "Also send the experimental VALIDATE command to each plugin")
+	validateCmd.Flags().BoolVar(&validateStrict, "strict", false, "Also fail on warnings, such as schema violations")
+	validateCmd.Flags().StringSliceVar(&validateCapabilities, "capabilities", nil, "Capabilities the runtime passes; warn about others that plugins declare")
+	rootCmd.AddCommand(validateCmd)
+}
+
+type loadedReport struct {
+	validateReport
+	list *libcni.NetworkConfigList
+}
+
+// loadValidateReports loads the configurations selected by target, which is
+// a network name, a file, a directory or empty for NETCONFPATH.
+func loadValidateReports(target string) ([]*loadedReport, error) {
+	netdir := netConfDir()
+	if target == "" {
+		return loadValidateDir(netdir)
+	}
+
+	info, err := os.Stat(target)
+	switch {
+	case err == nil && info.IsDir():
+		return loadValidateDir(target)
+	case err == nil:
+		return []*loadedReport{loadValidateFile(target)}, nil
+	}
+
+	list, err := libcni.LoadNetworkConf(netdir, target)
+	if err != nil {
+		return nil, err
+	}
+	return []*loadedReport{newLoadedReport("", list)}, nil
+}
+
+func loadValidateDir(dir string) ([]*loadedReport, error) {
+	configs, err := libcni.LoadNetworkConfigs(dir)
+	if err != nil {
+		return nil, err
+	}
+	shadowedBy := shadowingFiles(configs)
+
+	reports := make([]*loadedReport, 0, len(configs.Files))
+	for _, f := range configs.Files {
+		var r *loadedReport
+		if f.Err != nil {
+			r = &loadedReport{validateReport: validateReport{File: f.File, Error: f.Err.Error()}}
+		} else {
+			r = newLoadedReport(f.File, f.Config)
+		}
+		r.ShadowedBy = shadowedBy[f.File]
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
+
+func loadValidateFile(file string) *loadedReport {
+	list, err := libcni.LoadNetworkConfFile(file)
+	if err != nil {
+		return &loadedReport{validateReport: validateReport{File: file, Error: err.Error()}}
+	}
+	return newLoadedReport(file, list)
+}
+
+func newLoadedReport(file string, list *libcni.NetworkConfigList) *loadedReport {
+	return &loadedReport{
+		validateReport: validateReport{
+			Name:        list.Name,
+			File:        file,
+			CNIVersions: list.CNIVersions,
+		},
+		list: list,
+	}
+}
+
+func printValidateJSON(out io.Writer, reports []*loadedReport) error {
+	plain := make([]*validateReport, 0, len(reports))
+	for _, r := range reports {
+		plain = append(plain, &r.validateReport)
+	}
+	data, err := json.MarshalIndent(plain, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "%s\n", data)
+	return err
+}
+
+func printValidateText(out io.Writer, reports []*loadedReport) {
+	for _, r := range reports {
+		title := r.Name
+		if r.File != "" {
+			if title == "" {
+				title = r.File
+			} else {
+				title = fmt.Sprintf("%s (%s)", r.Name, r.File)
+			}
+		}
+		status := "OK"
+		if !r.Valid {
+			status = "INVALID"
+		}
+		fmt.Fprintf(out, "%s: %s\n", title, status)
+
+		if r.ShadowedBy != "" {
+			fmt.Fprintf(out, "  warning: not used, network is defined by %s\n", r.ShadowedBy)
+		}
+		if r.Error != "" {
+			fmt.Fprintf(out, "  error: %s\n", r.Error)
+			continue
+		}
+		fmt.Fprintf(out, "  cniVersion: %s\n", r.CNIVersion)
+		if len(r.CNIVersions) > 0 {
+			fmt.Fprintf(out, "  cniVersions: %s\n", strings.Join(r.CNIVersions, ", "))
+		}
+		if len(r.Capabilities) > 0 {
+			fmt.Fprintf(out, "  capabilities: %s\n", strings.Join(r.Capabilities, ", "))
+		}
+		for _, f := range r.Findings {
+			if f.Plugin < 0 {
+				fmt.Fprintf(out, "  %s: %s\n", f.Severity, f.Message)
+			} else {
+				fmt.Fprintf(out, "  %s: plugins[%d] (%s): %s\n", f.Severity, f.Plugin, f.PluginType, f.Message)
+			}
+		}
+	}
+}
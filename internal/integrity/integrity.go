@@ -0,0 +1,83 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package integrity holds the file checks shared by libcni, for
+// configuration files, and pkg/invoke, for plugin binaries.
+package integrity
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// FileID identifies a version of a file. A file that is modified in place,
+// or replaced through a rename, gets a new FileID.
+type FileID struct {
+	Size    int64
+	ModTime int64
+	Dev     uint64
+	Ino     uint64
+}
+
+// StatFileID returns the FileID of a file. Where device and inode numbers
+// are not available, the size and modification time alone identify it.
+func StatFileID(fi os.FileInfo) FileID {
+	dev, ino := fileDevIno(fi)
+	return FileID{
+		Size:    fi.Size(),
+		ModTime: fi.ModTime().UnixNano(),
+		Dev:     dev,
+		Ino:     ino,
+	}
+}
+
+// Digest returns the hex-encoded SHA-256 digest of data
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// IsDigest returns true if s is a hex-encoded SHA-256 digest
+func IsDigest(s string) bool {
+	b, err := hex.DecodeString(s)
+	return err == nil && len(b) == sha256.Size
+}
+
+// VerifySignature checks that sig is an ed25519 signature of data by one
+// of keys. The signature is either raw or base64-encoded, as read from a
+// signature file.
+func VerifySignature(data, sig []byte, keys []ed25519.PublicKey) error {
+	if len(sig) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(sig)))
+		if err != nil {
+			return fmt.Errorf("invalid signature encoding: %w", err)
+		}
+		sig = decoded
+	}
+	if len(sig) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid signature length %d", len(sig))
+	}
+	for _, key := range keys {
+		if ed25519.Verify(key, data, sig) {
+			return nil
+		}
+	}
+	return errors.New("signature does not match any public key")
+}
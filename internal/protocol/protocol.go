@@ -0,0 +1,77 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package protocol holds what pkg/skel, on the plugin side, and pkg/invoke,
+// on the runtime side, must agree on beyond the CNI specification. It only
+// depends on the standard library, so that importing it does not grow
+// plugin binaries.
+package protocol
+
+import "strings"
+
+const (
+	// EnvLogFD names the environment variable holding the file descriptor
+	// a plugin writes its log records to
+	EnvLogFD = "CNI_LOG_FD"
+	// EnvLogLevel names the environment variable holding the lowest level
+	// of log records the runtime is interested in, such as "DEBUG" or
+	// "WARN"
+	EnvLogLevel = "CNI_LOG_LEVEL"
+)
+
+// SocketRequest is a CNI request sent to a plugin served over a socket. It
+// carries what a plugin binary receives: its environment, in the form
+// returned by invoke.Args.AsEnv, and its stdin.
+type SocketRequest struct {
+	Env   []string `json:"env"`
+	Stdin []byte   `json:"stdin,omitempty"`
+}
+
+// SocketResponse is a served plugin's answer to a SocketRequest
+type SocketResponse struct {
+	Stdout []byte `json:"stdout,omitempty"`
+	Stderr []byte `json:"stderr,omitempty"`
+	// Failed takes the place of a nonzero exit status: Stdout then holds
+	// the error
+	Failed bool `json:"failed,omitempty"`
+}
+
+// Getenv returns a function looking up variables in environ, which is in
+// the form returned by os.Environ. As with os/exec, the last value of a
+// repeated variable wins.
+func Getenv(environ []string) func(string) string {
+	env := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	return func(key string) string {
+		return env[key]
+	}
+}
+
+// GetenvWithoutLogFD is Getenv for plugins that do not run in a process of
+// their own, such as those served over a socket or run in-process. EnvLogFD
+// is left out, since the descriptor it names is not one the runtime opened
+// for them, so they write their log records to stderr instead.
+func GetenvWithoutLogFD(environ []string) func(string) string {
+	getenv := Getenv(environ)
+	return func(key string) string {
+		if key == EnvLogFD {
+			return ""
+		}
+		return getenv(key)
+	}
+}
@@ -74,16 +74,33 @@ type NetworkConfig = PluginConfig
 type PluginConfig struct {
 	Network *types.PluginConf
 	Bytes   []byte
+	// Socket is the Unix socket of the daemon serving the plugin, set by
+	// its "cni.dev/socket" key. If empty, the plugin binary is executed.
+	Socket string
 }
 
 type NetworkConfigList struct {
-	Name                   string
-	CNIVersion             string
+	Name       string
+	CNIVersion string
+	// CNIVersions lists the versions accepted by the configuration through
+	// its "cniVersions" key (merged with "cniVersion"), in ascending order and
+	// excluding versions newer than this library. CNIVersion is the highest of
+	// them unless the caller set it to a lower one, for example to the
+	// version returned by NegotiateNetworkListVersion.
+	CNIVersions            []string
 	DisableCheck           bool
 	DisableGC              bool
 	LoadOnlyInlinedPlugins bool
 	Plugins                []*PluginConfig
-	Bytes                  []byte
+	// PluginSources is set when a ConfigLoader with PluginSources set loads
+	// a configuration list from a file, rather than converting a single
+	// plugin configuration. It has the file each plugin in Plugins was
+	// loaded from: the list's own file, an included fragment or a file in
+	// <dir>/<network-name>/.
+	PluginSources []string
+	// Bytes is the configuration list as JSON. For lists written in YAML it
+	// is the converted document, which is compact and has sorted keys.
+	Bytes []byte
 }
 
 type NetworkAttachment struct {
@@ -488,8 +505,7 @@ func (c *CNIConfig) GetCachedAttachments(containerID string) ([]*NetworkAttachme
 }
 
 func (c *CNIConfig) addNetwork(ctx context.Context, name, cniVersion string, net *PluginConfig, prevResult types.Result, rt *RuntimeConf) (types.Result, error) {
-	c.ensureExec()
-	pluginPath, err := c.exec.FindInPath(net.Network.Type, c.Path)
+	exec, pluginPath, err := c.findPlugin(net)
 	if err != nil {
 		return nil, err
 	}
@@ -508,7 +524,7 @@ func (c *CNIConfig) addNetwork(ctx context.Context, name, cniVersion string, net
 		return nil, err
 	}
 
-	return invoke.ExecPluginWithResult(ctx, pluginPath, newConf.Bytes, c.args("ADD", rt), c.exec)
+	return invoke.ExecPluginWithResult(ctx, pluginPath, newConf.Bytes, c.args("ADD", rt), exec)
 }
 
 // AddNetworkList executes a sequence of plugins with the ADD command
@@ -530,8 +546,7 @@ func (c *CNIConfig) AddNetworkList(ctx context.Context, list *NetworkConfigList,
 }
 
 func (c *CNIConfig) checkNetwork(ctx context.Context, name, cniVersion string, net *PluginConfig, prevResult types.Result, rt *RuntimeConf) error {
-	c.ensureExec()
-	pluginPath, err := c.exec.FindInPath(net.Network.Type, c.Path)
+	exec, pluginPath, err := c.findPlugin(net)
 	if err != nil {
 		return err
 	}
@@ -541,7 +556,7 @@ func (c *CNIConfig) checkNetwork(ctx context.Context, name, cniVersion string, n
 		return err
 	}
 
-	return invoke.ExecPluginWithoutResult(ctx, pluginPath, newConf.Bytes, c.args("CHECK", rt), c.exec)
+	return invoke.ExecPluginWithoutResult(ctx, pluginPath, newConf.Bytes, c.args("CHECK", rt), exec)
 }
 
 // CheckNetworkList executes a sequence of plugins with the CHECK command
@@ -572,8 +587,7 @@ func (c *CNIConfig) CheckNetworkList(ctx context.Context, list *NetworkConfigLis
 }
 
 func (c *CNIConfig) delNetwork(ctx context.Context, name, cniVersion string, net *PluginConfig, prevResult types.Result, rt *RuntimeConf) error {
-	c.ensureExec()
-	pluginPath, err := c.exec.FindInPath(net.Network.Type, c.Path)
+	exec, pluginPath, err := c.findPlugin(net)
 	if err != nil {
 		return err
 	}
@@ -583,7 +597,7 @@ func (c *CNIConfig) delNetwork(ctx context.Context, name, cniVersion string, net
 		return err
 	}
 
-	return invoke.ExecPluginWithoutResult(ctx, pluginPath, newConf.Bytes, c.args("DEL", rt), c.exec)
+	return invoke.ExecPluginWithoutResult(ctx, pluginPath, newConf.Bytes, c.args("DEL", rt), exec)
 }
 
 // DelNetworkList executes a sequence of plugins with the DEL command
@@ -680,37 +694,20 @@ func (c *CNIConfig) DelNetwork(ctx context.Context, net *PluginConfig, rt *Runti
 // - all the specified plugins exist on disk
 // - every plugin supports the desired version.
 //
-// Returns a list of all capabilities supported by the configuration, or error
+// Returns a list of all capabilities supported by the configuration, or the
+// problems found joined with errors.Join. Each joined error is a
+// *ValidationFinding; use ValidateNetworkListDetailed for warnings too.
+//
+// Earlier releases formatted the problems as a single list, as in
+// "[error1 error2]". The error text now has one problem per line, so
+// callers matching on it should use errors.As with *ValidationFinding
+// instead.
 func (c *CNIConfig) ValidateNetworkList(ctx context.Context, list *NetworkConfigList) ([]string, error) {
-	version := list.CNIVersion
-
-	// holding map for seen caps (in case of duplicates)
-	caps := map[string]interface{}{}
-
-	errs := []error{}
-	for _, net := range list.Plugins {
-		if err := c.validatePlugin(ctx, net.Network.Type, version); err != nil {
-			errs = append(errs, err)
-		}
-		for c, enabled := range net.Network.Capabilities {
-			if !enabled {
-				continue
-			}
-			caps[c] = struct{}{}
-		}
-	}
-
-	if len(errs) > 0 {
-		return nil, fmt.Errorf("%v", errs)
-	}
-
-	// make caps list
-	cc := make([]string, 0, len(caps))
-	for c := range caps {
-		cc = append(cc, c)
+	res := c.ValidateNetworkListDetailed(ctx, list, nil)
+	if err := res.Err(); err != nil {
+		return nil, err
 	}
-
-	return cc, nil
+	return res.Capabilities, nil
 }
 
 // ValidateNetwork checks that a configuration is reasonably valid.
@@ -723,24 +720,24 @@ func (c *CNIConfig) ValidateNetwork(ctx context.Context, net *PluginConfig) ([]s
 			caps = append(caps, c)
 		}
 	}
-	if err := c.validatePlugin(ctx, net.Network.Type, net.Network.CNIVersion); err != nil {
+	if err := c.validatePlugin(ctx, net); err != nil {
 		return nil, err
 	}
 	return caps, nil
 }
 
 // validatePlugin checks that an individual plugin's configuration is sane
-func (c *CNIConfig) validatePlugin(ctx context.Context, pluginName, expectedVersion string) error {
-	c.ensureExec()
-	pluginPath, err := c.exec.FindInPath(pluginName, c.Path)
+func (c *CNIConfig) validatePlugin(ctx context.Context, net *PluginConfig) error {
+	exec, pluginPath, err := c.findPlugin(net)
 	if err != nil {
 		return err
 	}
+	expectedVersion := net.Network.CNIVersion
 	if expectedVersion == "" {
 		expectedVersion = "0.1.0"
 	}
 
-	vi, err := invoke.GetVersionInfo(ctx, pluginPath, c.exec)
+	vi, err := c.pluginVersionInfo(ctx, net, exec, pluginPath)
 	if err != nil {
 		return err
 	}
@@ -749,11 +746,12 @@ func (c *CNIConfig) validatePlugin(ctx context.Context, pluginName, expectedVers
 			return nil
 		}
 	}
-	return fmt.Errorf("plugin %s does not support config version %q", pluginName, expectedVersion)
+	return fmt.Errorf("plugin %s does not support config version %q", net.Network.Type, expectedVersion)
 }
 
 // GetVersionInfo reports which versions of the CNI spec are supported by
-// the given plugin.
+// the given plugin. The result is cached for the process until the plugin
+// binary changes.
 func (c *CNIConfig) GetVersionInfo(ctx context.Context, pluginType string) (version.PluginInfo, error) {
 	c.ensureExec()
 	pluginPath, err := c.exec.FindInPath(pluginType, c.Path)
@@ -761,7 +759,7 @@ func (c *CNIConfig) GetVersionInfo(ctx context.Context, pluginType string) (vers
 		return nil, err
 	}
 
-	return invoke.GetVersionInfo(ctx, pluginPath, c.exec)
+	return c.getVersionInfo(ctx, pluginPath)
 }
 
 // GCNetworkList will do two things
@@ -842,14 +840,13 @@ func (c *CNIConfig) GCNetworkList(ctx context.Context, list *NetworkConfigList,
 }
 
 func (c *CNIConfig) gcNetwork(ctx context.Context, net *PluginConfig) error {
-	c.ensureExec()
-	pluginPath, err := c.exec.FindInPath(net.Network.Type, c.Path)
+	exec, pluginPath, err := c.findPlugin(net)
 	if err != nil {
 		return err
 	}
 	args := c.args("GC", &RuntimeConf{})
 
-	return invoke.ExecPluginWithoutResult(ctx, pluginPath, net.Bytes, args, c.exec)
+	return invoke.ExecPluginWithoutResult(ctx, pluginPath, net.Bytes, args, exec)
 }
 
 func (c *CNIConfig) GetStatusNetworkList(ctx context.Context, list *NetworkConfigList) error {
@@ -877,14 +874,13 @@ func (c *CNIConfig) GetStatusNetworkList(ctx context.Context, list *NetworkConfi
 }
 
 func (c *CNIConfig) getStatusNetwork(ctx context.Context, net *PluginConfig) error {
-	c.ensureExec()
-	pluginPath, err := c.exec.FindInPath(net.Network.Type, c.Path)
+	exec, pluginPath, err := c.findPlugin(net)
 	if err != nil {
 		return err
 	}
 	args := c.args("STATUS", &RuntimeConf{})
 
-	return invoke.ExecPluginWithoutResult(ctx, pluginPath, net.Bytes, args, c.exec)
+	return invoke.ExecPluginWithoutResult(ctx, pluginPath, net.Bytes, args, exec)
 }
 
 // =====
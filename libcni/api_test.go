@@ -1494,7 +1494,12 @@ var _ = Describe("Invoking plugins", func() {
 
 				netConfigList.Plugins[1].Network.Type = "nope"
 				_, err = cniConfig.ValidateNetworkList(ctx, netConfigList)
-				Expect(err).To(MatchError("[failed to find plugin \"nope\" in path [" + cniConfig.Path[0] + "]]"))
+				Expect(err).To(MatchError("failed to find plugin \"nope\" in path [" + cniConfig.Path[0] + "]"))
+
+				var finding *libcni.ValidationFinding
+				Expect(errors.As(err, &finding)).To(BeTrue())
+				Expect(finding.Kind).To(Equal(libcni.FindingMissingPlugin))
+				Expect(finding.Plugin).To(Equal(1))
 			})
 
 			It("Checks that the plugins support the needed version", func() {
@@ -1502,7 +1507,42 @@ var _ = Describe("Invoking plugins", func() {
 				_, err := cniConfig.ValidateNetworkList(ctx, netConfigList)
 
 				// The config list is just noop 3 times, so we get 3 errors
-				Expect(err).To(MatchError("[plugin noop does not support config version \"broken\" plugin noop does not support config version \"broken\" plugin noop does not support config version \"broken\"]"))
+				Expect(err).To(MatchError(strings.Repeat("plugin noop does not support config version \"broken\"\n", 2) +
+					"plugin noop does not support config version \"broken\""))
+			})
+
+			It("calls VALIDATE on every plugin when requested", func() {
+				res := cniConfig.ValidateNetworkListDetailed(ctx, netConfigList, nil)
+				Expect(res.Err()).NotTo(HaveOccurred())
+				debug, err := noop_debug.ReadDebug(plugins[0].debugFilePath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(debug.Command).To(BeEmpty())
+
+				res = cniConfig.ValidateNetworkListDetailed(ctx, netConfigList, &libcni.ValidateOptions{CallPlugins: true})
+				Expect(res.Err()).NotTo(HaveOccurred())
+				for _, p := range plugins {
+					debug, err := noop_debug.ReadDebug(p.debugFilePath)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(debug.Command).To(Equal("VALIDATE"))
+					Expect(debug.CmdArgs.ContainerID).To(BeEmpty())
+				}
+			})
+
+			It("reports plugins that reject their configuration", func() {
+				plugins[1].debug.ReportError = "bad range"
+				plugins[1].debug.ReportErrorCode = types.ErrInvalidNetworkConfig
+				Expect(plugins[1].debug.WriteDebug(plugins[1].debugFilePath)).To(Succeed())
+
+				res := cniConfig.ValidateNetworkListDetailed(ctx, netConfigList, &libcni.ValidateOptions{CallPlugins: true})
+				findings := res.FindingsAtLeast(libcni.SeverityError)
+				Expect(findings).To(HaveLen(1))
+				Expect(findings[0].Kind).To(Equal(libcni.FindingPluginRejected))
+				Expect(findings[0].Plugin).To(Equal(1))
+				Expect(res.Err()).To(MatchError("plugin noop rejected its configuration: bad range"))
+
+				var typesErr *types.Error
+				Expect(errors.As(res.Err(), &typesErr)).To(BeTrue())
+				Expect(typesErr.Code).To(Equal(types.ErrInvalidNetworkConfig))
 			})
 		})
 		Describe("GCNetworkList", func() {
@@ -18,7 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
+	"io/fs"
 	"path/filepath"
 	"slices"
 	"sort"
@@ -60,31 +60,29 @@ func NetworkPluginConfFromBytes(pluginConfBytes []byte) (*PluginConfig, error) {
 	if conf.Network.Type == "" {
 		return nil, fmt.Errorf("error parsing configuration: missing 'type'")
 	}
+	socket, data, err := pluginSocket(pluginConfBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing configuration: %w", err)
+	}
+	conf.Socket, conf.Bytes = socket, data
 	return conf, nil
 }
 
 // Given a path to a directory containing a network configuration, and the name of a network,
-// loads all plugin definitions found at path `networkConfPath/networkName/*.conf`
+// loads all plugin definitions found at path `networkConfPath/networkName/*.conf`,
+// ordered by their "cni.dev/priority" key or file name prefix.
 func NetworkPluginConfsFromFiles(networkConfPath, networkName string) ([]*PluginConfig, error) {
-	var pConfs []*PluginConfig
-
-	pluginConfPath := filepath.Join(networkConfPath, networkName)
+	return networkPluginConfsFromFiles(newConfFS(ConfigLoader{}), networkConfPath, networkName)
+}
 
-	pluginConfFiles, err := ConfFiles(pluginConfPath, []string{".conf"})
+func networkPluginConfsFromFiles(cfs confFS, networkConfPath, networkName string) ([]*PluginConfig, error) {
+	fragments, err := pluginFragments(cfs, networkConfPath, networkName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read plugin config files in %s: %w", pluginConfPath, err)
+		return nil, err
 	}
-
-	for _, pluginConfFile := range pluginConfFiles {
-		pluginConfBytes, err := os.ReadFile(pluginConfFile)
-		if err != nil {
-			return nil, fmt.Errorf("error reading %s: %w", pluginConfFile, err)
-		}
-		pluginConf, err := NetworkPluginConfFromBytes(pluginConfBytes)
-		if err != nil {
-			return nil, err
-		}
-		pConfs = append(pConfs, pluginConf)
+	pConfs := make([]*PluginConfig, 0, len(fragments))
+	for _, f := range fragments {
+		pConfs = append(pConfs, f.conf)
 	}
 	return pConfs, nil
 }
@@ -113,6 +111,7 @@ func NetworkConfFromBytes(confBytes []byte) (*NetworkConfigList, error) {
 		}
 	}
 
+	var cniVersions []string
 	rawVersions, ok := rawList["cniVersions"]
 	if ok {
 		// Parse the current package CNI version
@@ -154,8 +153,10 @@ func NetworkConfFromBytes(confBytes []byte) (*NetworkConfigList, error) {
 			}
 			return -1
 		})
+		vs = slices.Compact(vs)
 		if len(vs) > 0 {
 			cniVersion = vs[len(vs)-1]
+			cniVersions = vs
 		}
 	}
 
@@ -203,6 +204,7 @@ func NetworkConfFromBytes(confBytes []byte) (*NetworkConfigList, error) {
 		DisableGC:              disableGC,
 		LoadOnlyInlinedPlugins: loadOnlyInlinedPlugins,
 		CNIVersion:             cniVersion,
+		CNIVersions:            cniVersions,
 		Bytes:                  confBytes,
 	}
 
@@ -245,22 +247,44 @@ func NetworkConfFromBytes(confBytes []byte) (*NetworkConfigList, error) {
 }
 
 func NetworkConfFromFile(filename string) (*NetworkConfigList, error) {
-	bytes, err := os.ReadFile(filename)
+	return networkConfFromFile(newConfFS(ConfigLoader{}), filename)
+}
+
+func networkConfFromFile(cfs confFS, filename string) (*NetworkConfigList, error) {
+	bytes, err := cfs.ReadFile(filename)
 	if err != nil {
 		return nil, fmt.Errorf("error reading %s: %w", filename, err)
 	}
 
+	var sources []string
+	if cfs.Includes() {
+		bytes, sources, err = resolveIncludes(cfs, filename, bytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	conf, err := NetworkConfFromBytes(bytes)
 	if err != nil {
 		return nil, err
 	}
+	if sources == nil {
+		sources = make([]string, len(conf.Plugins))
+		for i := range sources {
+			sources[i] = filename
+		}
+	}
+	conf.PluginSources = sources
 
 	if !conf.LoadOnlyInlinedPlugins {
-		plugins, err := NetworkPluginConfsFromFiles(filepath.Dir(filename), conf.Name)
+		fragments, err := pluginFragments(cfs, cfs.Dir(filename), conf.Name)
+		if err != nil {
+			return nil, err
+		}
+		conf.Plugins, conf.PluginSources, err = placeFragments(conf.Plugins, conf.PluginSources, fragments)
 		if err != nil {
 			return nil, err
 		}
-		conf.Plugins = append(conf.Plugins, plugins...)
 	}
 
 	if len(conf.Plugins) == 0 {
@@ -268,6 +292,9 @@ func NetworkConfFromFile(filename string) (*NetworkConfigList, error) {
 		// but return as error for caller to decide, since they tried to load
 		return nil, fmt.Errorf("no plugin configs found")
 	}
+	if !cfs.PluginSources() {
+		conf.PluginSources = nil
+	}
 	return conf, nil
 }
 
@@ -278,7 +305,11 @@ func ConfFromBytes(bytes []byte) (*NetworkConfig, error) {
 
 // Deprecated: This file format is no longer supported, use NetworkConfXXX and NetworkPluginXXX functions
 func ConfFromFile(filename string) (*NetworkConfig, error) {
-	bytes, err := os.ReadFile(filename)
+	return confFromFile(newConfFS(ConfigLoader{}), filename)
+}
+
+func confFromFile(cfs confFS, filename string) (*NetworkConfig, error) {
+	bytes, err := cfs.ReadFile(filename)
 	if err != nil {
 		return nil, fmt.Errorf("error reading %s: %w", filename, err)
 	}
@@ -296,11 +327,15 @@ func ConfListFromFile(filename string) (*NetworkConfigList, error) {
 // ConfFiles simply returns a slice of all files in the provided directory
 // with extensions matching the provided set.
 func ConfFiles(dir string, extensions []string) ([]string, error) {
+	return confFiles(newConfFS(ConfigLoader{}), dir, extensions)
+}
+
+func confFiles(cfs confFS, dir string, extensions []string) ([]string, error) {
 	// In part, adapted from rkt/networking/podenv.go#listFiles
-	files, err := os.ReadDir(dir)
+	files, err := cfs.ReadDir(dir)
 	switch {
 	case err == nil: // break
-	case os.IsNotExist(err):
+	case errors.Is(err, fs.ErrNotExist):
 		// If folder not there, return no error - only return an
 		// error if we cannot read contents or there are no contents.
 		return nil, nil
@@ -316,7 +351,7 @@ func ConfFiles(dir string, extensions []string) ([]string, error) {
 		fileExt := filepath.Ext(f.Name())
 		for _, ext := range extensions {
 			if fileExt == ext {
-				confFiles = append(confFiles, filepath.Join(dir, f.Name()))
+				confFiles = append(confFiles, cfs.Join(dir, f.Name()))
 			}
 		}
 	}
@@ -325,7 +360,11 @@ func ConfFiles(dir string, extensions []string) ([]string, error) {
 
 // Deprecated: This file format is no longer supported, use NetworkConfXXX and NetworkPluginXXX functions
 func LoadConf(dir, name string) (*NetworkConfig, error) {
-	files, err := ConfFiles(dir, []string{".conf", ".json"})
+	return loadConf(newConfFS(ConfigLoader{}), dir, name)
+}
+
+func loadConf(cfs confFS, dir, name string) (*NetworkConfig, error) {
+	files, err := confFiles(cfs, dir, []string{".conf", ".json"})
 	switch {
 	case err != nil:
 		return nil, err
@@ -335,7 +374,7 @@ func LoadConf(dir, name string) (*NetworkConfig, error) {
 	sort.Strings(files)
 
 	for _, confFile := range files {
-		conf, err := ConfFromFile(confFile)
+		conf, err := confFromFile(cfs, confFile)
 		if err != nil {
 			return nil, err
 		}
@@ -353,17 +392,21 @@ func LoadConfList(dir, name string) (*NetworkConfigList, error) {
 // LoadNetworkConf looks at all the network configs in a given dir,
 // loads and parses them all, and returns the first one with an extension of `.conf`
 // that matches the provided network name predicate.
+// Use LoadNetworkConfigs to find the files that define the same network.
 func LoadNetworkConf(dir, name string) (*NetworkConfigList, error) {
+	return loadNetworkConf(newConfFS(ConfigLoader{}), dir, name)
+}
+
+func loadNetworkConf(cfs confFS, dir, name string) (*NetworkConfigList, error) {
 	// TODO this .conflist/.conf extension thing is confusing and inexact
 	// for implementors. We should pick one extension for everything and stick with it.
-	files, err := ConfFiles(dir, []string{".conflist"})
+	files, err := confListFiles(cfs, dir)
 	if err != nil {
 		return nil, err
 	}
-	sort.Strings(files)
 
 	for _, confFile := range files {
-		conf, err := NetworkConfFromFile(confFile)
+		conf, err := networkConfFromFile(cfs, confFile)
 		if err != nil {
 			return nil, err
 		}
@@ -374,7 +417,7 @@ func LoadNetworkConf(dir, name string) (*NetworkConfigList, error) {
 
 	// Deprecated: Try and load a network configuration file (instead of list)
 	// from the same name, then upconvert.
-	singleConf, err := LoadConf(dir, name)
+	singleConf, err := loadConf(cfs, dir, name)
 	if err != nil {
 		// A little extra logic so the error makes sense
 		var ncfErr NoConfigsFoundError
@@ -413,7 +456,12 @@ func InjectConf(original *PluginConfig, newValues map[string]interface{}) (*Plug
 		return nil, err
 	}
 
-	return NetworkPluginConfFromBytes(newBytes)
+	conf, err := NetworkPluginConfFromBytes(newBytes)
+	if err != nil {
+		return nil, err
+	}
+	conf.Socket = original.Socket
+	return conf, nil
 }
 
 // ConfListFromConf "upconverts" a network config in to a NetworkConfigList,
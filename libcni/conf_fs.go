@@ -0,0 +1,246 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// confFS is where the loaders read configuration files from: either the
+// operating system's file system, with OS paths, or an fs.FS, with
+// slash-separated paths.
+type confFS interface {
+	ReadDir(dir string) ([]fs.DirEntry, error)
+	ReadFile(name string) ([]byte, error)
+	Join(elem ...string) string
+	Dir(name string) string
+	// YAML is true if configuration lists written in YAML are loaded
+	YAML() bool
+	// Includes is true if include entries in plugin lists are resolved
+	Includes() bool
+	// PluginSources is true if the file each plugin is loaded from is
+	// recorded
+	PluginSources() bool
+}
+
+// newConfFS returns the confFS for a loader's file system. Files are
+// processed in order: they are verified if the loader has Verify set, YAML
+// configuration lists are converted to JSON if it has YAML set, then
+// placeholders are expanded if it has Expand set. Includes are resolved if
+// it has Includes set, and plugin sources are recorded if it has
+// PluginSources set.
+func newConfFS(l ConfigLoader) confFS {
+	var cfs confFS = osConfFS{}
+	if l.FS != nil {
+		cfs = ioConfFS{l.FS}
+	}
+	if l.Verify != nil {
+		cfs = verifyConfFS{confFS: cfs, opts: l.Verify}
+	}
+	if l.YAML {
+		cfs = yamlConfFS{cfs}
+	}
+	if l.Expand != nil {
+		cfs = expandConfFS{confFS: cfs, opts: l.Expand}
+	}
+	if l.Includes {
+		cfs = includeConfFS{cfs}
+	}
+	if l.PluginSources {
+		cfs = sourcesConfFS{cfs}
+	}
+	return cfs
+}
+
+type osConfFS struct{}
+
+func (osConfFS) ReadDir(dir string) ([]fs.DirEntry, error) { return os.ReadDir(dir) }
+func (osConfFS) ReadFile(name string) ([]byte, error)      { return os.ReadFile(name) }
+func (osConfFS) Join(elem ...string) string                { return filepath.Join(elem...) }
+func (osConfFS) Dir(name string) string                    { return filepath.Dir(name) }
+func (osConfFS) YAML() bool                                { return false }
+func (osConfFS) Includes() bool                            { return false }
+func (osConfFS) PluginSources() bool                       { return false }
+
+type ioConfFS struct {
+	fsys fs.FS
+}
+
+func (c ioConfFS) ReadDir(dir string) ([]fs.DirEntry, error) { return fs.ReadDir(c.fsys, dir) }
+func (c ioConfFS) ReadFile(name string) ([]byte, error)      { return fs.ReadFile(c.fsys, name) }
+func (ioConfFS) Join(elem ...string) string                  { return path.Join(elem...) }
+func (ioConfFS) Dir(name string) string                      { return path.Dir(name) }
+func (ioConfFS) YAML() bool                                  { return false }
+func (ioConfFS) Includes() bool                              { return false }
+func (ioConfFS) PluginSources() bool                         { return false }
+
+// The functions below load configurations from an fs.FS, such as an
+// embed.FS, an fstest.MapFS or the result of OverlayFS, and otherwise
+// behave like the functions of the same name without the FS suffix. Paths
+// are slash-separated and relative to the root of fsys, as for fs.FS, so
+// "." is the root directory. File names in results and errors use the
+// same form.
+
+// ConfFilesFS returns the files in dir with extensions matching the
+// provided set, like ConfFiles.
+func ConfFilesFS(fsys fs.FS, dir string, extensions []string) ([]string, error) {
+	return confFiles(newConfFS(ConfigLoader{FS: fsys}), dir, extensions)
+}
+
+// NetworkConfFromFS loads a configuration list from a file, including the
+// plugins found at `<dir of filename>/<network name>/*.conf`, like
+// NetworkConfFromFile.
+func NetworkConfFromFS(fsys fs.FS, filename string) (*NetworkConfigList, error) {
+	return networkConfFromFile(newConfFS(ConfigLoader{FS: fsys}), filename)
+}
+
+// NetworkPluginConfsFromFS loads all plugin definitions found at
+// `networkConfPath/networkName/*.conf`, like NetworkPluginConfsFromFiles.
+func NetworkPluginConfsFromFS(fsys fs.FS, networkConfPath, networkName string) ([]*PluginConfig, error) {
+	return networkPluginConfsFromFiles(newConfFS(ConfigLoader{FS: fsys}), networkConfPath, networkName)
+}
+
+// LoadNetworkConfFS returns the configuration for the named network in
+// dir, like LoadNetworkConf.
+func LoadNetworkConfFS(fsys fs.FS, dir, name string) (*NetworkConfigList, error) {
+	return loadNetworkConf(newConfFS(ConfigLoader{FS: fsys}), dir, name)
+}
+
+// LoadNetworkConfigsFS loads every network configuration in dir and reports
+// the conflicts between them, like LoadNetworkConfigs.
+func LoadNetworkConfigsFS(fsys fs.FS, dir string) (*NetworkConfigs, error) {
+	return loadNetworkConfigs(newConfFS(ConfigLoader{FS: fsys}), dir)
+}
+
+// ConfigLoader loads network configurations with optional processing of
+// every file before it is parsed. The zero value reads the operating
+// system's file system and behaves like the package-level functions.
+type ConfigLoader struct {
+	// FS, if set, is read instead of the operating system's file system,
+	// with slash-separated paths as for LoadNetworkConfFS
+	FS fs.FS
+	// Verify, if set, enables the verification of every file against its
+	// checksum or signature, see VerifyOptions
+	Verify *VerifyOptions
+	// YAML, if true, also loads configuration lists written in YAML, see
+	// YAMLConfListExtensions
+	YAML bool
+	// Includes, if true, replaces {"include": "path"} entries in plugin
+	// lists with the plugins of the fragment file at path, relative to the
+	// configuration file's directory
+	Includes bool
+	// PluginSources, if true, records the file each plugin is loaded from
+	// in NetworkConfigList.PluginSources
+	PluginSources bool
+	// Expand, if set, enables the expansion of placeholders in every file
+	// as described for ExpandConfig. YAML configuration lists are expanded
+	// once they are converted to JSON, where an unquoted placeholder such
+	// as "mtu: ${MTU}" has become a string, so placeholders in YAML always
+	// expand to strings. Numbers can only be templated in JSON files.
+	Expand *ExpandOptions
+}
+
+func (l *ConfigLoader) confFS() confFS {
+	return newConfFS(*l)
+}
+
+// ReadFile returns a file's contents after processing, which are the bytes
+// the loader parses and stores in NetworkConfigList.Bytes and
+// PluginConfig.Bytes.
+func (l *ConfigLoader) ReadFile(name string) ([]byte, error) {
+	return l.confFS().ReadFile(name)
+}
+
+// ConfFiles returns the files in dir with extensions matching the provided
+// set, like ConfFiles.
+func (l *ConfigLoader) ConfFiles(dir string, extensions []string) ([]string, error) {
+	return confFiles(l.confFS(), dir, extensions)
+}
+
+// NetworkConfFromFile loads a configuration list from a file, like
+// NetworkConfFromFile.
+func (l *ConfigLoader) NetworkConfFromFile(filename string) (*NetworkConfigList, error) {
+	return networkConfFromFile(l.confFS(), filename)
+}
+
+// NetworkPluginConfsFromFiles loads all plugin definitions found at
+// `networkConfPath/networkName/*.conf`, like NetworkPluginConfsFromFiles.
+func (l *ConfigLoader) NetworkPluginConfsFromFiles(networkConfPath, networkName string) ([]*PluginConfig, error) {
+	return networkPluginConfsFromFiles(l.confFS(), networkConfPath, networkName)
+}
+
+// LoadNetworkConf returns the configuration for the named network in dir,
+// like LoadNetworkConf.
+func (l *ConfigLoader) LoadNetworkConf(dir, name string) (*NetworkConfigList, error) {
+	return loadNetworkConf(l.confFS(), dir, name)
+}
+
+// LoadNetworkConfigs loads every network configuration in dir and reports
+// the conflicts between them, like LoadNetworkConfigs.
+func (l *ConfigLoader) LoadNetworkConfigs(dir string) (*NetworkConfigs, error) {
+	return loadNetworkConfigs(l.confFS(), dir)
+}
+
+// LoadNetworkConfFile loads a configuration list, or converts a legacy
+// single plugin configuration, like LoadNetworkConfFile.
+func (l *ConfigLoader) LoadNetworkConfFile(file string) (*NetworkConfigList, error) {
+	return loadNetworkConfFile(l.confFS(), file)
+}
+
+// expandConfFS expands placeholders in every file it reads
+type expandConfFS struct {
+	confFS
+	opts *ExpandOptions
+}
+
+func (c expandConfFS) ReadFile(name string) ([]byte, error) {
+	data, err := c.confFS.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return ExpandConfig(data, c.opts)
+}
+
+// yamlConfFS converts YAML configuration lists to JSON when reading them
+type yamlConfFS struct {
+	confFS
+}
+
+func (yamlConfFS) YAML() bool { return true }
+
+func (c yamlConfFS) ReadFile(name string) ([]byte, error) {
+	data, err := c.confFS.ReadFile(name)
+	if err != nil || !isYAMLConfList(name) {
+		return data, err
+	}
+	return yamlToJSON(data)
+}
+
+// includeConfFS enables the resolution of includes
+type includeConfFS struct {
+	confFS
+}
+
+func (includeConfFS) Includes() bool { return true }
+
+// sourcesConfFS enables the recording of plugin sources
+type sourcesConfFS struct {
+	confFS
+}
+
+func (sourcesConfFS) PluginSources() bool { return true }
@@ -0,0 +1,184 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+func mapFile(content string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(content), Mode: 0o644}
+}
+
+var _ = Describe("Loading configuration from an fs.FS", func() {
+	var fsys fstest.MapFS
+
+	BeforeEach(func() {
+		fsys = fstest.MapFS{
+			"net.d/10-a.conflist":       mapFile(`{"cniVersion": "1.0.0", "name": "a", "plugins": [{"type": "bridge"}]}`),
+			"net.d/a/10-portmap.conf":   mapFile(`{"type": "portmap"}`),
+			"net.d/20-b.conf":           mapFile(`{"cniVersion": "0.4.0", "name": "b", "type": "ptp"}`),
+			"net.d/30-broken.conflist":  mapFile(`{"name": `),
+			"net.d/README.md":           mapFile(`not a configuration`),
+			"net.d/sub/99-ign.conflist": mapFile(`{"cniVersion": "1.0.0", "name": "ign", "plugins": [{"type": "bridge"}]}`),
+		}
+	})
+
+	It("lists configuration files", func() {
+		files, err := libcni.ConfFilesFS(fsys, "net.d", []string{".conflist"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(Equal([]string{"net.d/10-a.conflist", "net.d/30-broken.conflist"}))
+
+		files, err = libcni.ConfFilesFS(fsys, "missing", []string{".conflist"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(BeEmpty())
+	})
+
+	It("loads a configuration list with its plugin directory", func() {
+		list, err := libcni.NetworkConfFromFS(fsys, "net.d/10-a.conflist")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Name).To(Equal("a"))
+		Expect(list.Plugins).To(HaveLen(2))
+		Expect(list.Plugins[1].Network.Type).To(Equal("portmap"))
+
+		plugins, err := libcni.NetworkPluginConfsFromFS(fsys, "net.d", "a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugins).To(HaveLen(1))
+
+		_, err = libcni.NetworkConfFromFS(fsys, "net.d/missing.conflist")
+		Expect(err).To(MatchError(ContainSubstring("error reading net.d/missing.conflist")))
+		Expect(err).To(MatchError(fs.ErrNotExist))
+	})
+
+	It("finds networks by name", func() {
+		delete(fsys, "net.d/30-broken.conflist")
+		list, err := libcni.LoadNetworkConfFS(fsys, "net.d", "b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Plugins[0].Network.Type).To(Equal("ptp"))
+
+		_, err = libcni.LoadNetworkConfFS(fsys, "net.d", "ign")
+		Expect(err).To(HaveOccurred())
+
+		_, err = libcni.LoadNetworkConfFS(fsys, "empty", "a")
+		Expect(err).To(Equal(libcni.NoConfigsFoundError{Dir: "empty"}))
+	})
+
+	It("loads every configuration", func() {
+		configs, err := libcni.LoadNetworkConfigsFS(fsys, "net.d")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configs.Names()).To(Equal([]string{"a", "b"}))
+		Expect(configs.Files[1].File).To(Equal("net.d/30-broken.conflist"))
+		Expect(configs.Files[1].Err).To(HaveOccurred())
+	})
+
+	It("agrees with the OS loaders for os.DirFS", func() {
+		dir := GinkgoT().TempDir()
+		for name, f := range fsys {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			Expect(os.MkdirAll(filepath.Dir(path), 0o700)).To(Succeed())
+			Expect(os.WriteFile(path, f.Data, 0o600)).To(Succeed())
+		}
+
+		fromFS, err := libcni.LoadNetworkConfFS(os.DirFS(dir), "net.d", "a")
+		Expect(err).NotTo(HaveOccurred())
+		fromOS, err := libcni.LoadNetworkConf(filepath.Join(dir, "net.d"), "a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fromFS).To(Equal(fromOS))
+	})
+})
+
+var _ = Describe("OverlayFS", func() {
+	var (
+		overrides fstest.MapFS
+		defaults  fstest.MapFS
+		overlay   fs.FS
+	)
+
+	BeforeEach(func() {
+		overrides = fstest.MapFS{
+			"10-a.conflist":     mapFile(`{"cniVersion": "1.0.0", "name": "a", "plugins": [{"type": "macvlan"}]}`),
+			"a/20-tuning.conf":  mapFile(`{"type": "tuning"}`),
+			"b":                 mapFile(`a file hiding the default directory`),
+			"c/10-portmap.conf": mapFile(`{"type": "bandwidth"}`),
+		}
+		defaults = fstest.MapFS{
+			"10-a.conflist":     mapFile(`{"cniVersion": "1.0.0", "name": "a", "plugins": [{"type": "bridge"}]}`),
+			"20-b.conflist":     mapFile(`{"cniVersion": "1.0.0", "name": "b", "plugins": [{"type": "bridge"}]}`),
+			"30-c.conflist":     mapFile(`{"cniVersion": "1.0.0", "name": "c"}`),
+			"a/10-portmap.conf": mapFile(`{"type": "portmap"}`),
+			"b/10-portmap.conf": mapFile(`{"type": "portmap"}`),
+			"c/10-portmap.conf": mapFile(`{"type": "portmap"}`),
+		}
+		overlay = libcni.OverlayFS(overrides, defaults)
+	})
+
+	It("is a valid file system", func() {
+		Expect(fstest.TestFS(overlay,
+			"10-a.conflist", "20-b.conflist", "30-c.conflist",
+			"a/10-portmap.conf", "a/20-tuning.conf", "b", "c/10-portmap.conf",
+		)).To(Succeed())
+	})
+
+	It("gives earlier layers precedence", func() {
+		data, err := fs.ReadFile(overlay, "10-a.conflist")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("macvlan"))
+
+		data, err = fs.ReadFile(overlay, "c/10-portmap.conf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("bandwidth"))
+
+		_, err = fs.ReadFile(overlay, "b/10-portmap.conf")
+		Expect(err).To(MatchError(fs.ErrNotExist))
+	})
+
+	It("merges directories", func() {
+		entries, err := fs.ReadDir(overlay, "a")
+		Expect(err).NotTo(HaveOccurred())
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		Expect(names).To(Equal([]string{"10-portmap.conf", "20-tuning.conf"}))
+	})
+
+	It("loads networks from every layer", func() {
+		delete(overrides, "b")
+		configs, err := libcni.LoadNetworkConfigsFS(overlay, ".")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configs.Names()).To(Equal([]string{"a", "b", "c"}))
+
+		var types []string
+		for _, p := range configs.Get("a").Plugins {
+			types = append(types, p.Network.Type)
+		}
+		Expect(types).To(Equal([]string{"macvlan", "portmap", "tuning"}))
+		Expect(configs.Get("b").Plugins).To(HaveLen(2))
+		Expect(configs.Get("c").Plugins[0].Network.Type).To(Equal("bandwidth"))
+	})
+
+	It("rejects invalid paths", func() {
+		_, err := overlay.Open("/10-a.conflist")
+		Expect(err).To(MatchError(fs.ErrInvalid))
+	})
+})
@@ -25,6 +25,7 @@ import (
 
 	"github.com/containernetworking/cni/libcni"
 	"github.com/containernetworking/cni/pkg/types"
+	"github.com/containernetworking/cni/pkg/version"
 )
 
 var _ = Describe("Loading configuration from disk", func() {
@@ -753,6 +754,25 @@ var _ = Describe("NetworkConfFromBytes", func() {
 			Expect(conf.CNIVersion).To(Equal("1.0.0"))
 		})
 
+		Context("when draft support is enabled", func() {
+			BeforeEach(func() {
+				DeferCleanup(version.SetDraftEnabled(true))
+			})
+
+			It("selects the draft version", func() {
+				conf, err := libcni.NetworkConfFromBytes(makeConfig("99.0.0", version.DraftVersion, "1.1.0"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf.CNIVersion).To(Equal(version.DraftVersion))
+			})
+		})
+
+		It("ignores the draft version unless draft support is enabled", func() {
+			DeferCleanup(version.SetDraftEnabled(false))
+			conf, err := libcni.NetworkConfFromBytes(makeConfig(version.DraftVersion, "1.1.0"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conf.CNIVersion).To(Equal("1.1.0"))
+		})
+
 		It("handles an empty cniVersions array", func() {
 			conf, err := libcni.NetworkConfFromBytes([]byte(`{"name": "test", "cniVersions": [], "plugins": [{"type": "foo"}]}`))
 			Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,373 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ExpandOptions configures the expansion of placeholders in configuration
+// files by ExpandConfig and ConfigLoader.
+type ExpandOptions struct {
+	// Vars are variables that take precedence over Lookup
+	Vars map[string]string
+	// Lookup returns the value of variables not in Vars. It defaults to
+	// os.LookupEnv; set it to a function returning false to only use Vars.
+	Lookup func(name string) (string, bool)
+}
+
+// UndefinedVariableError is returned when a placeholder refers to a variable
+// that is not set and has no default.
+type UndefinedVariableError struct {
+	Name string
+}
+
+func (e UndefinedVariableError) Error() string {
+	return fmt.Sprintf("undefined variable %q", e.Name)
+}
+
+// ExpandError reports a placeholder that could not be expanded
+type ExpandError struct {
+	// Line and Column locate the placeholder's "${", starting at 1
+	Line   int
+	Column int
+	// Expr is the text between "${" and "}"
+	Expr string
+	Err  error
+}
+
+func (e *ExpandError) Error() string {
+	return fmt.Sprintf("line %d, column %d: ${%s}: %v", e.Line, e.Column, e.Expr, e.Err)
+}
+
+func (e *ExpandError) Unwrap() error {
+	return e.Err
+}
+
+// ExpandConfig replaces the placeholders in a JSON configuration:
+//
+//	${NAME}            the value of variable NAME, which must be set
+//	${NAME:-default}   the value of NAME, or default if it is unset or empty
+//	${func(arg, ...)}  the result of a function
+//
+// Function arguments are variable names, 'single-quoted strings', integers
+// or function calls. The functions are:
+//
+//	lower(s), upper(s), trim(s)  change case or remove surrounding spaces
+//	cidrhost(prefix, n)          the n-th address of a CIDR prefix, e.g.
+//	                             cidrhost('10.1.2.0/24', 1) is 10.1.2.1
+//	cidrnetwork(prefix)          the prefix with its host bits cleared
+//
+// Inside a JSON string the value is escaped, so it cannot end the string.
+// Outside strings the value must be a number, true, false or null, as in
+// "mtu": ${MTU}. "$${" produces a literal "${". Any other "$" is left alone.
+func ExpandConfig(data []byte, opts *ExpandOptions) ([]byte, error) {
+	e := &expander{}
+	if opts != nil {
+		e.opts = *opts
+	}
+	if e.opts.Lookup == nil {
+		e.opts.Lookup = os.LookupEnv
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(data))
+	inString, escaped := false, false
+	line, lineStart := 1, 0
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case c == '\n':
+			line++
+			lineStart = i + 1
+		case inString && escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case c == '$' && bytes.HasPrefix(data[i+1:], []byte("${")):
+			// $${ is a literal ${
+			out.WriteString("${")
+			i += 2
+			continue
+		case c == '$' && i+1 < len(data) && data[i+1] == '{':
+			end := placeholderEnd(data, i+2)
+			if end < 0 {
+				return nil, &ExpandError{Line: line, Column: i - lineStart + 1, Expr: string(data[i+2:]), Err: errors.New("missing closing brace")}
+			}
+			expr := string(data[i+2 : end])
+			value, err := e.expand(expr, inString)
+			if err != nil {
+				return nil, &ExpandError{Line: line, Column: i - lineStart + 1, Expr: expr, Err: err}
+			}
+			out.WriteString(value)
+			i = end
+			continue
+		}
+		out.WriteByte(c)
+	}
+	return out.Bytes(), nil
+}
+
+// placeholderEnd returns the index of the brace closing a placeholder that
+// starts at data[start], skipping braces in quoted arguments, or -1.
+func placeholderEnd(data []byte, start int) int {
+	quoted := false
+	for i := start; i < len(data); i++ {
+		switch data[i] {
+		case '\'':
+			quoted = !quoted
+		case '\n':
+			return -1
+		case '}':
+			if !quoted {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+type expander struct {
+	opts ExpandOptions
+}
+
+func (e *expander) lookup(name string) (string, bool) {
+	if v, ok := e.opts.Vars[name]; ok {
+		return v, true
+	}
+	return e.opts.Lookup(name)
+}
+
+// expand evaluates a placeholder and encodes the value for its position
+func (e *expander) expand(expr string, inString bool) (string, error) {
+	var value string
+	if name, def, ok := strings.Cut(expr, ":-"); ok && isVarName(name) {
+		v, ok := e.lookup(name)
+		if !ok || v == "" {
+			v = def
+		}
+		value = v
+	} else {
+		p := &exprParser{e: e, s: expr}
+		v, err := p.parse()
+		if err != nil {
+			return "", err
+		}
+		value = v
+	}
+
+	if inString {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		// Drop the quotes added by Marshal
+		return string(b[1 : len(b)-1]), nil
+	}
+	switch value {
+	case "true", "false", "null":
+		return value, nil
+	}
+	if _, err := strconv.ParseFloat(value, 64); err != nil || !json.Valid([]byte(value)) {
+		return "", fmt.Errorf("value %q outside a JSON string must be a number, true, false or null", value)
+	}
+	return value, nil
+}
+
+func isVarName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// exprParser evaluates a placeholder expression
+type exprParser struct {
+	e   *expander
+	s   string
+	pos int
+}
+
+func (p *exprParser) parse() (string, error) {
+	v, err := p.value()
+	if err != nil {
+		return "", err
+	}
+	p.skipSpace()
+	if p.pos != len(p.s) {
+		return "", fmt.Errorf("unexpected %q", p.s[p.pos:])
+	}
+	return v, nil
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *exprParser) value() (string, error) {
+	p.skipSpace()
+	if p.pos == len(p.s) {
+		return "", errors.New("missing value")
+	}
+
+	switch c := p.s[p.pos]; {
+	case c == '\'':
+		end := strings.IndexByte(p.s[p.pos+1:], '\'')
+		if end < 0 {
+			return "", errors.New("unterminated string")
+		}
+		v := p.s[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return v, nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+			p.pos++
+		}
+		return p.s[start:p.pos], nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.s) && isVarName(p.s[start:p.pos+1]) {
+		p.pos++
+	}
+	name := p.s[start:p.pos]
+	if name == "" {
+		return "", fmt.Errorf("unexpected %q", p.s[p.pos:])
+	}
+
+	p.skipSpace()
+	if p.pos == len(p.s) || p.s[p.pos] != '(' {
+		v, ok := p.e.lookup(name)
+		if !ok {
+			return "", UndefinedVariableError{Name: name}
+		}
+		return v, nil
+	}
+	p.pos++
+
+	var args []string
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == ')' {
+		p.pos++
+	} else {
+		for {
+			arg, err := p.value()
+			if err != nil {
+				return "", err
+			}
+			args = append(args, arg)
+			p.skipSpace()
+			if p.pos == len(p.s) {
+				return "", fmt.Errorf("missing ')' after arguments to %s", name)
+			}
+			c := p.s[p.pos]
+			p.pos++
+			if c == ')' {
+				break
+			}
+			if c != ',' {
+				return "", fmt.Errorf("unexpected %q in arguments to %s", c, name)
+			}
+		}
+	}
+	return callExpandFunc(name, args)
+}
+
+func callExpandFunc(name string, args []string) (string, error) {
+	wantArgs := func(n int) error {
+		if len(args) != n {
+			return fmt.Errorf("%s takes %d arguments, got %d", name, n, len(args))
+		}
+		return nil
+	}
+
+	switch name {
+	case "lower", "upper", "trim":
+		if err := wantArgs(1); err != nil {
+			return "", err
+		}
+		switch name {
+		case "lower":
+			return strings.ToLower(args[0]), nil
+		case "upper":
+			return strings.ToUpper(args[0]), nil
+		}
+		return strings.TrimSpace(args[0]), nil
+	case "cidrnetwork":
+		if err := wantArgs(1); err != nil {
+			return "", err
+		}
+		prefix, err := netip.ParsePrefix(args[0])
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", name, err)
+		}
+		return prefix.Masked().String(), nil
+	case "cidrhost":
+		if err := wantArgs(2); err != nil {
+			return "", err
+		}
+		prefix, err := netip.ParsePrefix(args[0])
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", name, err)
+		}
+		n, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%s: invalid host number %q", name, args[1])
+		}
+		addr, ok := nthAddr(prefix.Masked(), n)
+		if !ok {
+			return "", fmt.Errorf("%s: host number %d is outside %s", name, n, prefix.Masked())
+		}
+		return addr.String(), nil
+	}
+	return "", fmt.Errorf("unknown function %q", name)
+}
+
+// nthAddr returns the address n after the start of prefix, if it is in it
+func nthAddr(prefix netip.Prefix, n uint64) (netip.Addr, bool) {
+	b := prefix.Addr().AsSlice()
+	carry := n
+	for i := len(b) - 1; i >= 0 && carry > 0; i-- {
+		sum := uint64(b[i]) + carry&0xff
+		b[i] = byte(sum)
+		carry = carry>>8 + sum>>8
+	}
+	if carry > 0 {
+		return netip.Addr{}, false
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr, prefix.Contains(addr)
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni_test
+
+import (
+	"errors"
+	"os"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+var _ = Describe("Expanding placeholders in configurations", func() {
+	var opts *libcni.ExpandOptions
+
+	BeforeEach(func() {
+		opts = &libcni.ExpandOptions{
+			Vars: map[string]string{
+				"POD_CIDR": "10.1.2.0/24",
+				"MTU":      "1450",
+				"IFACE":    "Eth0",
+				"QUOTED":   `a "quoted" \ value`,
+				"EMPTY":    "",
+			},
+			Lookup: func(string) (string, bool) { return "", false },
+		}
+	})
+
+	expand := func(in string) string {
+		out, err := libcni.ExpandConfig([]byte(in), opts)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return string(out)
+	}
+
+	It("substitutes variables", func() {
+		Expect(expand(`{"subnet": "${POD_CIDR}", "mtu": ${MTU}}`)).To(Equal(`{"subnet": "10.1.2.0/24", "mtu": 1450}`))
+		Expect(expand(`{"name": "net-${IFACE}-x"}`)).To(Equal(`{"name": "net-Eth0-x"}`))
+	})
+
+	It("escapes values inside strings", func() {
+		out := expand(`{"v": "${QUOTED}"}`)
+		Expect(out).To(Equal(`{"v": "a \"quoted\" \\ value"}`))
+	})
+
+	It("uses defaults for unset and empty variables", func() {
+		Expect(expand(`{"a": "${MISSING:-fallback}", "b": "${EMPTY:-x}", "c": ${MISSING:-9000}, "d": "${MTU:-1}"}`)).
+			To(Equal(`{"a": "fallback", "b": "x", "c": 9000, "d": "1450"}`))
+	})
+
+	It("calls functions", func() {
+		Expect(expand(`{"gw": "${cidrhost(POD_CIDR, 1)}", "net": "${cidrnetwork('10.1.2.3/16')}", "if": "${lower(IFACE)}"}`)).
+			To(Equal(`{"gw": "10.1.2.1", "net": "10.1.0.0/16", "if": "eth0"}`))
+		Expect(expand(`{"v": "${upper(trim(' a }b '))}", "v6": "${cidrhost('fd00::/64', 258)}"}`)).
+			To(Equal(`{"v": "A }B", "v6": "fd00::102"}`))
+	})
+
+	It("leaves escaped and bare dollar signs alone", func() {
+		Expect(expand(`{"a": "$${MTU}", "b": "$MTU", "c": "\${MTU}"}`)).
+			To(Equal(`{"a": "${MTU}", "b": "$MTU", "c": "\${MTU}"}`))
+	})
+
+	It("uses the environment by default", func() {
+		Expect(os.Setenv("CNI_TEST_EXPAND", "from-env")).To(Succeed())
+		DeferCleanup(os.Unsetenv, "CNI_TEST_EXPAND")
+
+		out, err := libcni.ExpandConfig([]byte(`{"v": "${CNI_TEST_EXPAND}"}`), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(Equal(`{"v": "from-env"}`))
+	})
+
+	It("reports undefined variables with their position", func() {
+		_, err := libcni.ExpandConfig([]byte("{\n  \"v\": \"${NOPE}\"\n}"), opts)
+		Expect(err).To(MatchError(`line 2, column 9: ${NOPE}: undefined variable "NOPE"`))
+
+		var undefined libcni.UndefinedVariableError
+		Expect(errors.As(err, &undefined)).To(BeTrue())
+		Expect(undefined.Name).To(Equal("NOPE"))
+
+		_, err = libcni.ExpandConfig([]byte(`{"v": "${lower(NOPE)}"}`), opts)
+		Expect(errors.As(err, &undefined)).To(BeTrue())
+	})
+
+	It("rejects values that would change the JSON structure", func() {
+		_, err := libcni.ExpandConfig([]byte(`{"mtu": ${IFACE}}`), opts)
+		Expect(err).To(MatchError(ContainSubstring(`value "Eth0" outside a JSON string must be a number`)))
+
+		opts.Vars["INJECT"] = `1, "type": "evil"`
+		_, err = libcni.ExpandConfig([]byte(`{"mtu": ${INJECT}}`), opts)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects invalid expressions", func() {
+		for in, msg := range map[string]string{
+			`{"v": "${MTU`:                           "missing closing brace",
+			`{"v": "${exec('rm')}"}`:                 `unknown function "exec"`,
+			`{"v": "${lower(MTU, MTU)}"}`:            "lower takes 1 arguments, got 2",
+			`{"v": "${cidrhost(POD_CIDR, 256)}"}`:    "cidrhost: host number 256 is outside 10.1.2.0/24",
+			`{"v": "${cidrhost(IFACE, 1)}"}`:         "cidrhost: netip.ParsePrefix",
+			`{"v": "${lower(MTU}"}`:                  "missing ')' after arguments to lower",
+			`{"v": "${MTU MTU}"}`:                    `unexpected "MTU"`,
+			`{"v": "${'unterminated}"}`:              "missing closing brace",
+			`{"v": "${}"}`:                           "missing value",
+			`{"v": "${cidrhost(POD_CIDR, 'x')}"}`:    `cidrhost: invalid host number "x"`,
+			`{"v": "${cidrnetwork('10.0.0.0/33')}"}`: "cidrnetwork: netip.ParsePrefix",
+		} {
+			_, err := libcni.ExpandConfig([]byte(in), opts)
+			Expect(err).To(MatchError(ContainSubstring(msg)), in)
+		}
+	})
+
+	Describe("ConfigLoader", func() {
+		It("expands every file it loads", func() {
+			fsys := fstest.MapFS{
+				"10-net.conflist":     mapFile(`{"cniVersion": "1.0.0", "name": "net-${lower(IFACE)}", "plugins": [{"type": "bridge", "mtu": ${MTU}}]}`),
+				"net-eth0/20-gw.conf": mapFile(`{"type": "tuning", "gateway": "${cidrhost(POD_CIDR, 1)}"}`),
+			}
+			loader := &libcni.ConfigLoader{FS: fsys, Expand: opts}
+
+			list, err := loader.LoadNetworkConf(".", "net-eth0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(list.Plugins[0].Bytes)).To(Equal(`{"mtu":1450,"type":"bridge"}`))
+			Expect(string(list.Plugins[1].Bytes)).To(Equal(`{"type": "tuning", "gateway": "10.1.2.1"}`))
+
+			expanded, err := loader.ReadFile("10-net.conflist")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.Bytes).To(Equal(expanded))
+			Expect(string(expanded)).To(ContainSubstring(`"mtu": 1450`))
+		})
+
+		It("reports the file that failed to expand", func() {
+			fsys := fstest.MapFS{
+				"10-net.conflist": mapFile(`{"cniVersion": "1.0.0", "name": "${NAME}", "plugins": [{"type": "bridge"}]}`),
+			}
+			loader := &libcni.ConfigLoader{FS: fsys, Expand: opts}
+
+			_, err := loader.NetworkConfFromFile("10-net.conflist")
+			Expect(err).To(MatchError(`error reading 10-net.conflist: line 1, column 34: ${NAME}: undefined variable "NAME"`))
+
+			configs, err := loader.LoadNetworkConfigs(".")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configs.Files[0].Err).To(MatchError(libcni.UndefinedVariableError{Name: "NAME"}))
+		})
+
+		It("does not expand unless enabled", func() {
+			fsys := fstest.MapFS{
+				"10-net.conflist": mapFile(`{"cniVersion": "1.0.0", "name": "${NAME}", "plugins": [{"type": "bridge"}]}`),
+			}
+			list, err := (&libcni.ConfigLoader{FS: fsys}).NetworkConfFromFile("10-net.conflist")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.Name).To(Equal("${NAME}"))
+		})
+	})
+})
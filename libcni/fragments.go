@@ -0,0 +1,240 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Plugin configurations loaded from `<dir>/<network-name>/*.conf` are
+// ordered by file name. Those setting the integer "cni.dev/priority" key
+// are moved before the ones with a higher priority; the default is 0, so
+// configurations without the key keep their order relative to each other.
+//
+// By default they are added after the plugins in the list itself. The
+// "cni.dev/position" key places them elsewhere:
+//
+//	"first"          before every plugin in the list
+//	"last"           after every plugin in the list (the default)
+//	"before:<type>"  just before the first plugin of that type
+//	"after:<type>"   just after the last plugin of that type
+//
+// Only the list's own plugins, including those from included fragments,
+// can be referred to by type. Plugin configurations placed at the same
+// position keep their relative order. Both keys are removed from the
+// configuration passed to the plugin, as keys starting with "cni.dev/" are
+// reserved for the runtime. NetworkConfigList.PluginSources shows where
+// each plugin came from.
+const (
+	pluginPriorityKey = "cni.dev/priority"
+	pluginPositionKey = "cni.dev/position"
+)
+
+// pluginFragment is a plugin configuration loaded from the network's
+// directory
+type pluginFragment struct {
+	file     string
+	conf     *PluginConfig
+	priority int
+	// position is "first", "last", "before" or "after"
+	position string
+	// target is the plugin type for "before" and "after"
+	target string
+}
+
+func loadPluginFragment(cfs confFS, file string) (*pluginFragment, error) {
+	data, err := cfs.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", file, err)
+	}
+
+	f := &pluginFragment{
+		file:     file,
+		position: "last",
+	}
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err == nil {
+		rawPriority, hasPriority := raw[pluginPriorityKey]
+		rawPosition, hasPosition := raw[pluginPositionKey]
+		if hasPriority {
+			p, ok := decodeNumber(rawPriority).(json.Number)
+			i, err := p.Int64()
+			if !ok || err != nil || i < math.MinInt32 || i > math.MaxInt32 {
+				return nil, fmt.Errorf("error parsing %s: invalid %s %s: must be an integer", file, pluginPriorityKey, rawPriority)
+			}
+			f.priority = int(i)
+		}
+		if hasPosition {
+			var s string
+			_ = json.Unmarshal(rawPosition, &s)
+			if err := f.parsePosition(s); err != nil {
+				return nil, fmt.Errorf("error parsing %s: %w", file, err)
+			}
+		}
+		if hasPriority || hasPosition {
+			data = removeKeys(data, pluginPriorityKey, pluginPositionKey)
+		}
+	}
+
+	f.conf, err = NetworkPluginConfFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *pluginFragment) parsePosition(s string) error {
+	switch s {
+	case "first", "last":
+		f.position = s
+		return nil
+	}
+	position, target, _ := strings.Cut(s, ":")
+	if (position != "before" && position != "after") || target == "" {
+		return fmt.Errorf("invalid %s %q: must be first, last, before:<type> or after:<type>", pluginPositionKey, s)
+	}
+	f.position, f.target = position, target
+	return nil
+}
+
+// removeKeys removes top-level keys from a JSON object, leaving the rest
+// of the document as it is written
+func removeKeys(data []byte, keys ...string) []byte {
+	type member struct {
+		start, end int
+		remove     bool
+	}
+	var members []member
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return data
+	}
+	for dec.More() {
+		offset := int(dec.InputOffset())
+		key, err := dec.Token()
+		if err != nil {
+			return data
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return data
+		}
+		members = append(members, member{
+			// Skip the comma and spaces before the key
+			start:  offset + bytes.IndexByte(data[offset:], '"'),
+			end:    int(dec.InputOffset()),
+			remove: slices.Contains(keys, key.(string)),
+		})
+	}
+	if len(members) == 0 {
+		return data
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:members[0].start]...)
+	prev := -1
+	for i, m := range members {
+		if m.remove {
+			continue
+		}
+		if prev >= 0 {
+			// Keep the separator that followed the previous member
+			out = append(out, data[members[prev].end:members[prev+1].start]...)
+		}
+		out = append(out, data[m.start:m.end]...)
+		prev = i
+	}
+	return append(out, data[members[len(members)-1].end:]...)
+}
+
+// pluginFragments loads the plugin configurations in the network's
+// directory, in priority order
+func pluginFragments(cfs confFS, networkConfPath, networkName string) ([]*pluginFragment, error) {
+	pluginConfPath := cfs.Join(networkConfPath, networkName)
+	files, err := confFiles(cfs, pluginConfPath, []string{".conf"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin config files in %s: %w", pluginConfPath, err)
+	}
+	sort.Strings(files)
+
+	// Files are in lexical order, which the stable sort keeps for plugins
+	// with the same priority
+	fragments := make([]*pluginFragment, 0, len(files))
+	for _, file := range files {
+		f, err := loadPluginFragment(cfs, file)
+		if err != nil {
+			return nil, err
+		}
+		fragments = append(fragments, f)
+	}
+	sort.SliceStable(fragments, func(i, j int) bool {
+		return fragments[i].priority < fragments[j].priority
+	})
+	return fragments, nil
+}
+
+// placeFragments adds plugin configurations loaded from the network's
+// directory to the list's plugins, at their requested positions
+func placeFragments(plugins []*PluginConfig, sources []string, fragments []*pluginFragment) ([]*PluginConfig, []string, error) {
+	first := map[string]int{}
+	last := map[string]int{}
+	for i, p := range plugins {
+		if _, ok := first[p.Network.Type]; !ok {
+			first[p.Network.Type] = i
+		}
+		last[p.Network.Type] = i
+	}
+
+	positioned := map[string][]*pluginFragment{}
+	for _, f := range fragments {
+		key := f.position
+		if f.target != "" {
+			if _, ok := first[f.target]; !ok {
+				return nil, nil, fmt.Errorf("error placing %s %s:%s: no plugin of type %q in the list", f.file, f.position, f.target, f.target)
+			}
+			key += ":" + f.target
+		}
+		positioned[key] = append(positioned[key], f)
+	}
+
+	outPlugins := make([]*PluginConfig, 0, len(plugins)+len(fragments))
+	outSources := make([]string, 0, len(plugins)+len(fragments))
+	add := func(key string) {
+		for _, f := range positioned[key] {
+			outPlugins = append(outPlugins, f.conf)
+			outSources = append(outSources, f.file)
+		}
+	}
+
+	add("first")
+	for i, p := range plugins {
+		if first[p.Network.Type] == i {
+			add("before:" + p.Network.Type)
+		}
+		outPlugins = append(outPlugins, p)
+		outSources = append(outSources, sources[i])
+		if last[p.Network.Type] == i {
+			add("after:" + p.Network.Type)
+		}
+	}
+	add("last")
+	return outPlugins, outSources, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni_test
+
+import (
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+var _ = Describe("Ordering plugins loaded from the network's directory", func() {
+	var fsys fstest.MapFS
+
+	BeforeEach(func() {
+		fsys = fstest.MapFS{
+			"10-net.conflist": mapFile(`{"cniVersion": "1.0.0", "name": "net", "plugins": [{"type": "bridge"}, {"type": "portmap"}, {"type": "bridge"}]}`),
+		}
+	})
+
+	load := func() (*libcni.NetworkConfigList, error) {
+		return (&libcni.ConfigLoader{FS: fsys, PluginSources: true}).NetworkConfFromFile("10-net.conflist")
+	}
+
+	It("orders by file name", func() {
+		fsys["net/10-b.conf"] = mapFile(`{"type": "ten"}`)
+		fsys["net/9-a.conf"] = mapFile(`{"type": "nine"}`)
+		fsys["net/bridge.conf"] = mapFile(`{"type": "unnumbered"}`)
+
+		list, err := load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pluginTypes(list)).To(Equal([]string{"bridge", "portmap", "bridge", "ten", "nine", "unnumbered"}))
+		Expect(list.PluginSources).To(Equal([]string{
+			"10-net.conflist", "10-net.conflist", "10-net.conflist",
+			"net/10-b.conf", "net/9-a.conf", "net/bridge.conf",
+		}))
+
+		plugins, err := libcni.NetworkPluginConfsFromFS(fsys, ".", "net")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugins[1].Network.Type).To(Equal("nine"))
+	})
+
+	It("only records plugin sources when enabled", func() {
+		fsys["net/10-a.conf"] = mapFile(`{"type": "tuning"}`)
+		list, err := libcni.NetworkConfFromFS(fsys, "10-net.conflist")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Plugins).To(HaveLen(4))
+		Expect(list.PluginSources).To(BeNil())
+	})
+
+	It("orders by priority, then by file name", func() {
+		fsys["net/10-a.conf"] = mapFile(`{"type": "a", "cni.dev/priority": 50}`)
+		fsys["net/20-b.conf"] = mapFile(`{"type": "b", "cni.dev/priority": -1}`)
+		fsys["net/30-c.conf"] = mapFile(`{"type": "c"}`)
+		fsys["net/40-d.conf"] = mapFile(`{"type": "d", "cni.dev/priority": 30}`)
+
+		list, err := load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pluginTypes(list)[3:]).To(Equal([]string{"b", "c", "d", "a"}))
+	})
+
+	It("places plugins before or after the list's plugins", func() {
+		fsys["net/10-first.conf"] = mapFile(`{"type": "first", "cni.dev/position": "first"}`)
+		fsys["net/20-first.conf"] = mapFile(`{"type": "first2", "cni.dev/position": "first"}`)
+		fsys["net/30-before.conf"] = mapFile(`{"type": "tuning", "cni.dev/position": "before:bridge"}`)
+		fsys["net/40-after.conf"] = mapFile(`{"type": "firewall", "cni.dev/position": "after:bridge"}`)
+		fsys["net/50-after.conf"] = mapFile(`{"type": "bandwidth", "cni.dev/position": "after:bridge"}`)
+		fsys["net/60-portmap.conf"] = mapFile(`{"type": "sbr", "cni.dev/position": "before:portmap"}`)
+		fsys["net/70-last.conf"] = mapFile(`{"type": "last", "cni.dev/position": "last"}`)
+
+		list, err := load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pluginTypes(list)).To(Equal([]string{
+			"first", "first2", "tuning", "bridge", "sbr", "portmap", "bridge", "firewall", "bandwidth", "last",
+		}))
+		Expect(list.PluginSources[3]).To(Equal("10-net.conflist"))
+		Expect(list.PluginSources[4]).To(Equal("net/60-portmap.conf"))
+	})
+
+	It("removes the ordering keys from the plugin configuration", func() {
+		fsys["net/10-a.conf"] = mapFile(`{"type": "tuning", "cni.dev/priority": 5, "cni.dev/position": "first", "mtu": 1400}`)
+		fsys["net/20-b.conf"] = mapFile(`{"type": "firewall", "priority": 7}`)
+
+		list, err := load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(list.Plugins[0].Bytes)).To(Equal(`{"type": "tuning", "mtu": 1400}`))
+		Expect(string(list.Plugins[4].Bytes)).To(Equal(`{"type": "firewall", "priority": 7}`))
+
+		fsys["net/10-a.conf"] = mapFile(`{"type": "tuning", "id": 9007199254740993, "cni.dev/position": "last" }`)
+		list, err = load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(list.Plugins[3].Bytes)).To(Equal(`{"type": "tuning", "id": 9007199254740993 }`))
+	})
+
+	It("rejects invalid ordering", func() {
+		for conf, msg := range map[string]string{
+			`{"type": "a", "cni.dev/priority": "1"}`:             `error parsing net/10-a.conf: invalid cni.dev/priority "1": must be an integer`,
+			`{"type": "a", "cni.dev/priority": 1.5}`:             `invalid cni.dev/priority 1.5: must be an integer`,
+			`{"type": "a", "cni.dev/position": "middle"}`:        `invalid cni.dev/position "middle": must be first, last, before:<type> or after:<type>`,
+			`{"type": "a", "cni.dev/position": "before:"}`:       `invalid cni.dev/position "before:"`,
+			`{"type": "a", "cni.dev/position": 1}`:               `invalid cni.dev/position ""`,
+			`{"type": "a", "cni.dev/position": "after:ptp"}`:     `error placing net/10-a.conf after:ptp: no plugin of type "ptp" in the list`,
+			`{"type": "a", "cni.dev/position": "before:tuning"}`: `no plugin of type "tuning" in the list`,
+		} {
+			fsys["net/10-a.conf"] = mapFile(conf)
+			fsys["net/20-b.conf"] = mapFile(`{"type": "tuning"}`)
+			_, err := load()
+			Expect(err).To(MatchError(ContainSubstring(msg)), conf)
+		}
+	})
+})
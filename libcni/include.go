@@ -0,0 +1,228 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// Plugin lists loaded from files may include plugins from shared fragment
+// files, so that several networks can use the same chain of plugins:
+//
+//	"plugins": [
+//	    {"type": "bridge", ...},
+//	    {"include": "includes/chain-tail.json"}
+//	]
+//
+// The included plugins replace the include entry, keeping their position in
+// the list. An include entry must have no other keys. Its path is
+// slash-separated and relative to the directory of the configuration file,
+// and may not contain "..". A fragment is either a single plugin
+// configuration, with a "type", or an object with a "plugins" list, which
+// may itself include other fragments. Including a fragment from itself,
+// directly or through other fragments, is an error.
+//
+// Fragments should be kept in a subdirectory, such as includes/, so that
+// they are not loaded as network configurations themselves.
+//
+// Includes are resolved when a ConfigLoader with Includes set loads a
+// configuration from a file, and the list's Bytes hold the resolved
+// configuration. The package-level loaders do not resolve includes.
+
+// IncludeCycleError is returned when a fragment includes itself
+type IncludeCycleError struct {
+	// Chain lists the files from the configuration file to the repeated
+	// fragment
+	Chain []string
+}
+
+func (e IncludeCycleError) Error() string {
+	return fmt.Sprintf("include cycle: %s", strings.Join(e.Chain, " -> "))
+}
+
+// resolveIncludes replaces include entries in a configuration list's plugins
+// with the plugins they refer to, and returns the file each plugin came
+// from. Lists without includes are returned unchanged, with nil sources.
+// Otherwise only the "plugins" value is replaced, and plugins are copied as
+// they are written, so that the rest of the document keeps its key order
+// and numbers.
+func resolveIncludes(cfs confFS, filename string, data []byte) ([]byte, []string, error) {
+	start, end, ok := pluginsValue(data)
+	if !ok {
+		// Let NetworkConfFromBytes report the error
+		return data, nil, nil
+	}
+	var plugins []json.RawMessage
+	if err := json.Unmarshal(data[start:end], &plugins); err != nil || !hasInclude(plugins) {
+		return data, nil, nil
+	}
+
+	r := &includeResolver{cfs: cfs, dir: cfs.Dir(filename)}
+	plugins, sources, err := r.resolve(plugins, []string{filename})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing configuration list: %w", err)
+	}
+	resolved := make([]byte, 0, len(data))
+	resolved = append(resolved, data[:start]...)
+	resolved = appendPlugins(resolved, plugins)
+	resolved = append(resolved, data[end:]...)
+	return resolved, sources, nil
+}
+
+// pluginsValue returns the location of the top-level "plugins" value in a
+// JSON object
+func pluginsValue(data []byte) (int, int, bool) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return 0, 0, false
+	}
+	start, end, found := 0, 0, false
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return 0, 0, false
+		}
+		// The value starts after the colon following the key
+		offset := int(dec.InputOffset())
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return 0, 0, false
+		}
+		// As for json.Unmarshal, the last of duplicate keys is used
+		if key == "plugins" {
+			start = offset + bytes.IndexByte(data[offset:], ':') + 1
+			end, found = int(dec.InputOffset()), true
+		}
+	}
+	return start, end, found
+}
+
+func appendPlugins(b []byte, plugins []json.RawMessage) []byte {
+	b = append(b, '[')
+	for i, p := range plugins {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, p...)
+	}
+	return append(b, ']')
+}
+
+// includeEntry returns the raw value of a plugin's "include" key, and the
+// number of keys the plugin has. ok is false if it is not an include.
+func includeEntry(plugin json.RawMessage) (json.RawMessage, int, bool) {
+	m := map[string]json.RawMessage{}
+	if err := json.Unmarshal(plugin, &m); err != nil {
+		return nil, 0, false
+	}
+	include, ok := m["include"]
+	return include, len(m), ok
+}
+
+func hasInclude(plugins []json.RawMessage) bool {
+	for _, p := range plugins {
+		if _, _, ok := includeEntry(p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+type includeResolver struct {
+	cfs confFS
+	dir string
+}
+
+// resolve expands the include entries in plugins and returns the file
+// each resulting plugin is defined in. stack is the chain of files being
+// resolved, used to detect cycles.
+func (r *includeResolver) resolve(plugins []json.RawMessage, stack []string) ([]json.RawMessage, []string, error) {
+	resolved := make([]json.RawMessage, 0, len(plugins))
+	sources := make([]string, 0, len(plugins))
+	current := stack[len(stack)-1]
+	for _, p := range plugins {
+		rawInclude, keys, ok := includeEntry(p)
+		if !ok {
+			resolved = append(resolved, p)
+			sources = append(sources, current)
+			continue
+		}
+
+		var include string
+		if err := json.Unmarshal(rawInclude, &include); err != nil {
+			return nil, nil, fmt.Errorf("invalid include type %T in %s", decodeNumber(rawInclude), current)
+		}
+		if keys != 1 {
+			return nil, nil, fmt.Errorf("include %q in %s must not have other keys", include, current)
+		}
+		if !fs.ValidPath(include) || include == "." {
+			return nil, nil, fmt.Errorf("invalid include path %q in %s: must be relative to the configuration directory and not contain \"..\"", include, current)
+		}
+
+		file := r.cfs.Join(r.dir, include)
+		for i, f := range stack {
+			if f == file {
+				chain := append(append([]string{}, stack[i:]...), file)
+				return nil, nil, IncludeCycleError{Chain: chain}
+			}
+		}
+
+		fragment, fragmentSources, err := r.load(file, append(stack, file))
+		if err != nil {
+			return nil, nil, err
+		}
+		resolved = append(resolved, fragment...)
+		sources = append(sources, fragmentSources...)
+	}
+	return resolved, sources, nil
+}
+
+// load reads a fragment and returns its plugins
+func (r *includeResolver) load(file string, stack []string) ([]json.RawMessage, []string, error) {
+	data, err := r.cfs.ReadFile(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading include %s: %w", file, err)
+	}
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("error parsing include %s: %w", file, err)
+	}
+
+	if rawPlugins, ok := raw["plugins"]; ok {
+		var plugins []json.RawMessage
+		if err := json.Unmarshal(rawPlugins, &plugins); err != nil {
+			return nil, nil, fmt.Errorf("error parsing include %s: invalid 'plugins' type %T", file, decodeNumber(rawPlugins))
+		}
+		return r.resolve(plugins, stack)
+	}
+	if _, ok := raw["type"]; ok {
+		return []json.RawMessage{bytes.TrimSpace(data)}, []string{file}, nil
+	}
+	return nil, nil, fmt.Errorf("error parsing include %s: missing 'type' or 'plugins'", file)
+}
+
+// decodeNumber decodes a JSON value for error messages, with numbers as
+// json.Number
+func decodeNumber(data []byte) interface{} {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	_ = dec.Decode(&v)
+	return v
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+func pluginTypes(list *libcni.NetworkConfigList) []string {
+	var types []string
+	for _, p := range list.Plugins {
+		types = append(types, p.Network.Type)
+	}
+	return types
+}
+
+var _ = Describe("Including plugin fragments", func() {
+	var (
+		fsys   fstest.MapFS
+		loader *libcni.ConfigLoader
+	)
+
+	BeforeEach(func() {
+		fsys = fstest.MapFS{
+			"includes/tail.json":     mapFile(`{"plugins": [{"type": "portmap", "capabilities": {"portMappings": true}}, {"include": "includes/firewall.json"}]}`),
+			"includes/firewall.json": mapFile(`{"type": "firewall"}`),
+			"includes/head.json":     mapFile(`{"type": "tuning", "mtu": 1450}`),
+			"10-a.conflist":          mapFile(`{"cniVersion": "1.0.0", "name": "a", "plugins": [{"type": "bridge"}, {"include": "includes/tail.json"}]}`),
+			"20-b.conflist":          mapFile(`{"cniVersion": "1.0.0", "name": "b", "plugins": [{"include": "includes/head.json"}, {"type": "ptp"}, {"include": "includes/tail.json"}]}`),
+		}
+		loader = &libcni.ConfigLoader{FS: fsys, Includes: true}
+	})
+
+	It("replaces include entries with the fragment's plugins in order", func() {
+		a, err := loader.LoadNetworkConf(".", "a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pluginTypes(a)).To(Equal([]string{"bridge", "portmap", "firewall"}))
+
+		b, err := loader.LoadNetworkConf(".", "b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pluginTypes(b)).To(Equal([]string{"tuning", "ptp", "portmap", "firewall"}))
+		Expect(b.Plugins[0].Bytes).To(MatchJSON(`{"type": "tuning", "mtu": 1450}`))
+		Expect(b.Bytes).To(MatchJSON(`{
+			"cniVersion": "1.0.0",
+			"name": "b",
+			"plugins": [
+				{"type": "tuning", "mtu": 1450},
+				{"type": "ptp"},
+				{"type": "portmap", "capabilities": {"portMappings": true}},
+				{"type": "firewall"}
+			]
+		}`))
+	})
+
+	It("leaves lists without includes unchanged", func() {
+		data := []byte(`{"cniVersion": "1.0.0", "name": "c", "plugins": [ {"type": "bridge"} ]}`)
+		fsys["30-c.conflist"] = &fstest.MapFile{Data: data}
+		c, err := loader.NetworkConfFromFile("30-c.conflist")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Bytes).To(Equal(data))
+	})
+
+	It("resolves includes from the configuration file's directory", func() {
+		dir := GinkgoT().TempDir()
+		for name, f := range fsys {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			Expect(os.MkdirAll(filepath.Dir(path), 0o700)).To(Succeed())
+			Expect(os.WriteFile(path, f.Data, 0o600)).To(Succeed())
+		}
+		a, err := (&libcni.ConfigLoader{Includes: true}).LoadNetworkConf(dir, "a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pluginTypes(a)).To(Equal([]string{"bridge", "portmap", "firewall"}))
+	})
+
+	It("keeps the rest of the list as written", func() {
+		fsys["30-c.conflist"] = mapFile(`{"name": "c", "cniVersion": "1.0.0", "big": 9007199254740993, "plugins": [{"type": "bridge", "mtu": 9007199254740993}, {"include": "includes/head.json"}], "disableCheck": true}`)
+		c, err := loader.NetworkConfFromFile("30-c.conflist")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(c.Bytes)).To(Equal(`{"name": "c", "cniVersion": "1.0.0", "big": 9007199254740993, "plugins":[{"type": "bridge", "mtu": 9007199254740993},{"type": "tuning", "mtu": 1450}], "disableCheck": true}`))
+		Expect(c.DisableCheck).To(BeTrue())
+	})
+
+	It("does not resolve includes unless enabled", func() {
+		_, err := libcni.LoadNetworkConfFS(fsys, ".", "a")
+		Expect(err).To(MatchError(ContainSubstring("missing 'type'")))
+	})
+
+	It("detects include cycles", func() {
+		fsys["includes/x.json"] = mapFile(`{"plugins": [{"type": "tuning"}, {"include": "includes/y.json"}]}`)
+		fsys["includes/y.json"] = mapFile(`{"plugins": [{"include": "includes/x.json"}]}`)
+		fsys["30-c.conflist"] = mapFile(`{"cniVersion": "1.0.0", "name": "c", "plugins": [{"include": "includes/x.json"}]}`)
+
+		_, err := loader.NetworkConfFromFile("30-c.conflist")
+		Expect(err).To(MatchError("error parsing configuration list: include cycle: includes/x.json -> includes/y.json -> includes/x.json"))
+		var cycle libcni.IncludeCycleError
+		Expect(errors.As(err, &cycle)).To(BeTrue())
+
+		fsys["30-c.conflist"] = mapFile(`{"cniVersion": "1.0.0", "name": "c", "plugins": [{"include": "30-c.conflist"}]}`)
+		_, err = loader.NetworkConfFromFile("30-c.conflist")
+		Expect(err).To(MatchError(ContainSubstring("include cycle: 30-c.conflist -> 30-c.conflist")))
+	})
+
+	It("rejects invalid includes", func() {
+		for include, msg := range map[string]string{
+			`{"include": "../etc/passwd"}`:                   `invalid include path "../etc/passwd"`,
+			`{"include": "/etc/passwd"}`:                     `invalid include path "/etc/passwd"`,
+			`{"include": 1}`:                                 "invalid include type json.Number in 30-c.conflist",
+			`{"include": "includes/head.json", "type": "x"}`: `include "includes/head.json" in 30-c.conflist must not have other keys`,
+			`{"include": "includes/missing.json"}`:           "error reading include includes/missing.json",
+			`{"include": "includes/empty.json"}`:             "error parsing include includes/empty.json: missing 'type' or 'plugins'",
+			`{"include": "includes/bad.json"}`:               "error parsing include includes/bad.json: invalid 'plugins' type string",
+		} {
+			fsys["includes/empty.json"] = mapFile(`{}`)
+			fsys["includes/bad.json"] = mapFile(`{"plugins": "x"}`)
+			fsys["30-c.conflist"] = mapFile(`{"cniVersion": "1.0.0", "name": "c", "plugins": [` + include + `]}`)
+			_, err := loader.NetworkConfFromFile("30-c.conflist")
+			Expect(err).To(MatchError(ContainSubstring(msg)), include)
+		}
+	})
+})
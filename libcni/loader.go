@@ -0,0 +1,209 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NetworkConfigFile is a configuration file found by LoadNetworkConfigs
+type NetworkConfigFile struct {
+	// File is the path of the configuration file
+	File string
+	// Legacy is true for single plugin .conf and .json files, which are
+	// converted to a list with one plugin
+	Legacy bool
+	// Config is the loaded configuration, or nil if it could not be loaded
+	Config *NetworkConfigList
+	// Err is the reason the file could not be loaded
+	Err error
+	// Effective is true if this file is the first one in Files that loaded
+	// and defines its network name. LoadNetworkConf loads the same file,
+	// unless a file it considers earlier can not be loaded, in which case
+	// it returns that file's error instead.
+	Effective bool
+}
+
+// ConflictKind classifies a ConfigConflict
+type ConflictKind string
+
+const (
+	// ConflictDuplicateName means several files of the same kind define
+	// the same network name and only the first in lexical order is used
+	ConflictDuplicateName ConflictKind = "DuplicateName"
+	// ConflictLegacyShadowed means .conf or .json files define a network
+	// name that is also defined by a .conflist file, which is used instead
+	ConflictLegacyShadowed ConflictKind = "LegacyShadowed"
+)
+
+// ConfigConflict reports configuration files that are ignored because
+// another file defines the same network
+type ConfigConflict struct {
+	Kind ConflictKind
+	Name string
+	// Effective is the file used for the network
+	Effective string
+	// Shadowed are the ignored files, in lexical order
+	Shadowed []string
+}
+
+func (c *ConfigConflict) Error() string {
+	switch c.Kind {
+	case ConflictLegacyShadowed:
+		return fmt.Sprintf("network %q: legacy configuration %s ignored in favor of %s",
+			c.Name, strings.Join(c.Shadowed, ", "), c.Effective)
+	default:
+		return fmt.Sprintf("network %q: %s ignored in favor of %s",
+			c.Name, strings.Join(c.Shadowed, ", "), c.Effective)
+	}
+}
+
+// NetworkConfigs is every network configuration found in a directory
+type NetworkConfigs struct {
+	Dir string
+	// Files lists .conflist files followed by legacy .conf and .json
+	// files, each in lexical order, which is the order LoadNetworkConf
+	// considers them in.
+	Files []*NetworkConfigFile
+	// Conflicts lists the network names defined by more than one file
+	Conflicts []*ConfigConflict
+}
+
+// Get returns the effective configuration for the named network, or nil
+func (n *NetworkConfigs) Get(name string) *NetworkConfigList {
+	for _, f := range n.Files {
+		if f.Effective && f.Config.Name == name {
+			return f.Config
+		}
+	}
+	return nil
+}
+
+// Names returns the names of all networks with an effective configuration,
+// in the order their files are considered.
+func (n *NetworkConfigs) Names() []string {
+	var names []string
+	for _, f := range n.Files {
+		if f.Effective {
+			names = append(names, f.Config.Name)
+		}
+	}
+	return names
+}
+
+// LoadNetworkConfigs loads every network configuration in a directory and
+// reports which files provide each network and which are shadowed. Unlike
+// LoadNetworkConf, files that fail to load are recorded rather than causing
+// an error. An error is only returned if the directory cannot be read or
+// contains no configuration files.
+func LoadNetworkConfigs(dir string) (*NetworkConfigs, error) {
+	return loadNetworkConfigs(newConfFS(ConfigLoader{}), dir)
+}
+
+func loadNetworkConfigs(cfs confFS, dir string) (*NetworkConfigs, error) {
+	conflists, err := confListFiles(cfs, dir)
+	if err != nil {
+		return nil, err
+	}
+	legacy, err := confFiles(cfs, dir, []string{".conf", ".json"})
+	if err != nil {
+		return nil, err
+	}
+	if len(conflists)+len(legacy) == 0 {
+		return nil, NoConfigsFoundError{Dir: dir}
+	}
+	sort.Strings(legacy)
+
+	configs := &NetworkConfigs{Dir: dir}
+	for _, file := range conflists {
+		f := &NetworkConfigFile{File: file}
+		f.Config, f.Err = networkConfFromFile(cfs, file)
+		configs.Files = append(configs.Files, f)
+	}
+	for _, file := range legacy {
+		f := &NetworkConfigFile{File: file, Legacy: true}
+		f.Config, f.Err = loadLegacyConfFile(cfs, file)
+		configs.Files = append(configs.Files, f)
+	}
+
+	effective := map[string]*NetworkConfigFile{}
+	var names []string
+	shadowed := map[string][]*NetworkConfigFile{}
+	for _, f := range configs.Files {
+		if f.Err != nil {
+			continue
+		}
+		name := f.Config.Name
+		if _, ok := effective[name]; ok {
+			shadowed[name] = append(shadowed[name], f)
+			continue
+		}
+		f.Effective = true
+		effective[name] = f
+		names = append(names, name)
+	}
+
+	for _, name := range names {
+		winner := effective[name]
+		var dups, legacies []string
+		for _, f := range shadowed[name] {
+			if f.Legacy && !winner.Legacy {
+				legacies = append(legacies, f.File)
+			} else {
+				dups = append(dups, f.File)
+			}
+		}
+		if len(dups) > 0 {
+			configs.Conflicts = append(configs.Conflicts, &ConfigConflict{
+				Kind:      ConflictDuplicateName,
+				Name:      name,
+				Effective: winner.File,
+				Shadowed:  dups,
+			})
+		}
+		if len(legacies) > 0 {
+			configs.Conflicts = append(configs.Conflicts, &ConfigConflict{
+				Kind:      ConflictLegacyShadowed,
+				Name:      name,
+				Effective: winner.File,
+				Shadowed:  legacies,
+			})
+		}
+	}
+	return configs, nil
+}
+
+// LoadNetworkConfFile loads a configuration list from a .conflist file, or
+// converts a legacy single plugin .conf or .json file to a list.
+func LoadNetworkConfFile(file string) (*NetworkConfigList, error) {
+	return loadNetworkConfFile(newConfFS(ConfigLoader{}), file)
+}
+
+func loadNetworkConfFile(cfs confFS, file string) (*NetworkConfigList, error) {
+	if isConfListFile(cfs, file) {
+		return networkConfFromFile(cfs, file)
+	}
+	return loadLegacyConfFile(cfs, file)
+}
+
+func loadLegacyConfFile(cfs confFS, file string) (*NetworkConfigList, error) {
+	conf, err := confFromFile(cfs, file)
+	if err != nil {
+		return nil, err
+	}
+	return ConfListFromConf(conf)
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+var _ = Describe("Loading all network configurations", func() {
+	var configDir string
+
+	BeforeEach(func() {
+		configDir = GinkgoT().TempDir()
+	})
+
+	write := func(name, content string) string {
+		path := filepath.Join(configDir, name)
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return path
+	}
+
+	It("reports every file and the conflicts between them", func() {
+		a1 := write("10-a.conflist", `{"cniVersion": "1.0.0", "name": "a", "plugins": [{"type": "bridge"}]}`)
+		a2 := write("20-a.conflist", `{"cniVersion": "1.0.0", "name": "a", "plugins": [{"type": "ptp"}]}`)
+		bad := write("30-bad.conflist", `{"name": `)
+		b := write("40-b.conflist", `{"cniVersion": "1.0.0", "name": "b", "plugins": [{"type": "macvlan"}]}`)
+		aLegacy := write("00-a.conf", `{"cniVersion": "0.4.0", "name": "a", "type": "bridge"}`)
+		c1 := write("50-c.conf", `{"cniVersion": "0.4.0", "name": "c", "type": "bridge"}`)
+		c2 := write("60-c.json", `{"cniVersion": "0.4.0", "name": "c", "type": "ptp"}`)
+
+		configs, err := libcni.LoadNetworkConfigs(configDir)
+		Expect(err).NotTo(HaveOccurred())
+
+		var files []string
+		var effective []bool
+		for _, f := range configs.Files {
+			files = append(files, f.File)
+			effective = append(effective, f.Effective)
+		}
+		Expect(files).To(Equal([]string{a1, a2, bad, b, aLegacy, c1, c2}))
+		Expect(effective).To(Equal([]bool{true, false, false, true, false, true, false}))
+		Expect(configs.Files[2].Err).To(HaveOccurred())
+		Expect(configs.Files[4].Legacy).To(BeTrue())
+
+		Expect(configs.Names()).To(Equal([]string{"a", "b", "c"}))
+		Expect(configs.Get("a").Plugins[0].Network.Type).To(Equal("bridge"))
+		Expect(configs.Get("c").Plugins[0].Network.Type).To(Equal("bridge"))
+		Expect(configs.Get("missing")).To(BeNil())
+
+		Expect(configs.Conflicts).To(Equal([]*libcni.ConfigConflict{
+			{Kind: libcni.ConflictDuplicateName, Name: "a", Effective: a1, Shadowed: []string{a2}},
+			{Kind: libcni.ConflictLegacyShadowed, Name: "a", Effective: a1, Shadowed: []string{aLegacy}},
+			{Kind: libcni.ConflictDuplicateName, Name: "c", Effective: c1, Shadowed: []string{c2}},
+		}))
+		Expect(configs.Conflicts[1]).To(MatchError(`network "a": legacy configuration ` + aLegacy + ` ignored in favor of ` + a1))
+	})
+
+	It("agrees with LoadNetworkConf", func() {
+		write("10-a.conflist", `{"cniVersion": "1.0.0", "name": "a", "plugins": [{"type": "bridge"}]}`)
+		write("20-a.conflist", `{"cniVersion": "1.0.0", "name": "a", "plugins": [{"type": "ptp"}]}`)
+		write("00-b.conf", `{"cniVersion": "0.4.0", "name": "b", "type": "bridge"}`)
+
+		configs, err := libcni.LoadNetworkConfigs(configDir)
+		Expect(err).NotTo(HaveOccurred())
+		for _, name := range configs.Names() {
+			list, err := libcni.LoadNetworkConf(configDir, name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configs.Get(name)).To(Equal(list))
+		}
+	})
+
+	It("skips files LoadNetworkConf fails on", func() {
+		write("10-bad.conflist", `{"name": `)
+		b := write("20-b.conflist", `{"cniVersion": "1.0.0", "name": "b", "plugins": [{"type": "bridge"}]}`)
+
+		configs, err := libcni.LoadNetworkConfigs(configDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configs.Files[0].Err).To(HaveOccurred())
+		Expect(configs.Files[1].File).To(Equal(b))
+		Expect(configs.Files[1].Effective).To(BeTrue())
+
+		_, err = libcni.LoadNetworkConf(configDir, "b")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails when there are no configuration files", func() {
+		_, err := libcni.LoadNetworkConfigs(configDir)
+		Expect(err).To(MatchError(libcni.NoConfigsFoundError{Dir: configDir}))
+	})
+})
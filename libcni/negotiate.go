@@ -0,0 +1,110 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/containernetworking/cni/pkg/version"
+)
+
+// NoCommonVersionError is returned by NegotiateNetworkListVersion when no
+// version accepted by the configuration is supported by every plugin.
+type NoCommonVersionError struct {
+	Network string
+	// Configured lists the versions accepted by the configuration
+	Configured []string
+	// Supported maps each plugin type to the versions it reported
+	Supported map[string][]string
+}
+
+func (e *NoCommonVersionError) Error() string {
+	types := make([]string, 0, len(e.Supported))
+	for t := range e.Supported {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	parts := make([]string, 0, len(types))
+	for _, t := range types {
+		parts = append(parts, fmt.Sprintf("plugin %s supports %q", t, e.Supported[t]))
+	}
+	return fmt.Sprintf("no CNI version supported by all plugins of network %q: config accepts %q; %s",
+		e.Network, e.Configured, strings.Join(parts, "; "))
+}
+
+// NegotiateNetworkListVersion returns the highest version accepted by the
+// configuration (see NetworkConfigList.CNIVersions) that is supported by
+// this library and by every plugin in the list. The list is not modified;
+// callers that want to use the version set list.CNIVersion to it. Plugins
+// are probed with the VERSION command; the results are cached for the
+// process until the plugin binaries change, so repeated negotiations, even
+// through other CNIConfigs, do not re-execute them. A *NoCommonVersionError
+// is returned if there is no such version.
+func (c *CNIConfig) NegotiateNetworkListVersion(ctx context.Context, list *NetworkConfigList) (string, error) {
+	candidates := list.CNIVersions
+	if len(candidates) == 0 {
+		v := list.CNIVersion
+		if v == "" {
+			v = "0.1.0"
+		}
+		candidates = []string{v}
+	}
+
+	supported := map[string][]string{}
+	for _, net := range list.Plugins {
+		pluginType := net.Network.Type
+		if _, ok := supported[pluginType]; ok {
+			continue
+		}
+		exec, pluginPath, err := c.findPlugin(net)
+		if err != nil {
+			return "", fmt.Errorf("failed to get version info of plugin %s: %w", pluginType, err)
+		}
+		vi, err := c.pluginVersionInfo(ctx, net, exec, pluginPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to get version info of plugin %s: %w", pluginType, err)
+		}
+		supported[pluginType] = vi.SupportedVersions()
+	}
+
+	// Candidates are sorted in ascending order
+	for i := len(candidates) - 1; i >= 0; i-- {
+		v := candidates[i]
+		if gt, err := version.GreaterThan(v, version.Current()); err != nil || gt {
+			continue
+		}
+		ok := true
+		for _, versions := range supported {
+			if !slices.Contains(versions, v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return v, nil
+		}
+	}
+
+	return "", &NoCommonVersionError{
+		Network:    list.Name,
+		Configured: candidates,
+		Supported:  supported,
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/containernetworking/cni/pkg/version"
+)
+
+// versionExec is an invoke.Exec whose plugins only answer VERSION, each
+// reporting a fixed list of supported versions. The plugin binaries are
+// empty files in a temporary directory.
+type versionExec struct {
+	dir      string
+	versions map[string][]string
+	calls    map[string]int
+}
+
+func newVersionExec(versions map[string][]string) *versionExec {
+	dir := GinkgoT().TempDir()
+	for name := range versions {
+		Expect(os.WriteFile(filepath.Join(dir, name), nil, 0o755)).To(Succeed())
+	}
+	libcni.ClearPluginVersionCache()
+	return &versionExec{
+		dir:      dir,
+		versions: versions,
+		calls:    map[string]int{},
+	}
+}
+
+func (e *versionExec) ExecPlugin(_ context.Context, pluginPath string, _ []byte, environ []string) ([]byte, error) {
+	Expect(environ).To(ContainElement("CNI_COMMAND=VERSION"))
+	name := filepath.Base(pluginPath)
+	e.calls[name]++
+	return []byte(fmt.Sprintf(`{"cniVersion":"1.1.0","supportedVersions":["%s"]}`,
+		strings.Join(e.versions[name], `","`))), nil
+}
+
+func (e *versionExec) FindInPath(plugin string, paths []string) (string, error) {
+	if _, ok := e.versions[plugin]; !ok {
+		return "", fmt.Errorf("failed to find plugin %q in path %s", plugin, paths)
+	}
+	return filepath.Join(paths[0], plugin), nil
+}
+
+func (e *versionExec) Decode(jsonBytes []byte) (version.PluginInfo, error) {
+	return (&version.PluginDecoder{}).Decode(jsonBytes)
+}
+
+var _ = Describe("Negotiating the network version", func() {
+	var (
+		exec      *versionExec
+		cniConfig *libcni.CNIConfig
+		ctx       context.Context
+	)
+
+	BeforeEach(func() {
+		exec = newVersionExec(map[string][]string{
+			"old": {"0.3.1", "0.4.0"},
+			"new": {"0.4.0", "1.0.0", "1.1.0"},
+		})
+		cniConfig = libcni.NewCNIConfig([]string{exec.dir}, exec)
+		ctx = context.TODO()
+	})
+
+	makeList := func(versions string, types ...string) *libcni.NetworkConfigList {
+		plugins := make([]string, 0, len(types))
+		for _, t := range types {
+			plugins = append(plugins, fmt.Sprintf(`{"type": %q}`, t))
+		}
+		list, err := libcni.NetworkConfFromBytes([]byte(fmt.Sprintf(`{"name": "test", "cniVersions": [%s], "plugins": [%s]}`,
+			versions, strings.Join(plugins, ","))))
+		Expect(err).NotTo(HaveOccurred())
+		return list
+	}
+
+	It("records the configured versions", func() {
+		list := makeList(`"1.0.0", "0.3.1", "99.0.0", "0.4.0"`, "new")
+		Expect(list.CNIVersions).To(Equal([]string{"0.3.1", "0.4.0", "1.0.0"}))
+		Expect(list.CNIVersion).To(Equal("1.0.0"))
+	})
+
+	It("selects the highest version supported by all plugins", func() {
+		list := makeList(`"0.3.1", "0.4.0", "1.0.0", "1.1.0"`, "new", "old", "new")
+		Expect(list.CNIVersion).To(Equal("1.1.0"))
+
+		v, err := cniConfig.NegotiateNetworkListVersion(ctx, list)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal("0.4.0"))
+		Expect(list.CNIVersion).To(Equal("1.1.0"))
+	})
+
+	It("caches the probe results", func() {
+		list := makeList(`"0.4.0", "1.0.0"`, "new", "old")
+		for i := 0; i < 3; i++ {
+			_, err := cniConfig.NegotiateNetworkListVersion(ctx, list)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(exec.calls).To(Equal(map[string]int{"new": 1, "old": 1}))
+	})
+
+	It("falls back to cniVersion without cniVersions", func() {
+		list, err := libcni.NetworkConfFromBytes([]byte(`{"name": "test", "cniVersion": "1.0.0", "plugins": [{"type": "new"}]}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		v, err := cniConfig.NegotiateNetworkListVersion(ctx, list)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal("1.0.0"))
+	})
+
+	It("returns a clear error when there is no common version", func() {
+		list := makeList(`"1.0.0", "1.1.0"`, "new", "old")
+
+		_, err := cniConfig.NegotiateNetworkListVersion(ctx, list)
+		var ncvErr *libcni.NoCommonVersionError
+		Expect(errors.As(err, &ncvErr)).To(BeTrue())
+		Expect(ncvErr.Configured).To(Equal([]string{"1.0.0", "1.1.0"}))
+		Expect(ncvErr.Supported).To(HaveKeyWithValue("old", []string{"0.3.1", "0.4.0"}))
+		Expect(err).To(MatchError(`no CNI version supported by all plugins of network "test": config accepts ["1.0.0" "1.1.0"]; ` +
+			`plugin new supports ["0.4.0" "1.0.0" "1.1.0"]; plugin old supports ["0.3.1" "0.4.0"]`))
+		Expect(list.CNIVersion).To(Equal("1.1.0"))
+	})
+
+	It("fails when a plugin cannot be found", func() {
+		list := makeList(`"1.0.0"`, "missing")
+		_, err := cniConfig.NegotiateNetworkListVersion(ctx, list)
+		Expect(err).To(MatchError(ContainSubstring(`failed to find plugin "missing"`)))
+	})
+})
@@ -0,0 +1,161 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+)
+
+// OverlayFS combines several file systems into one, for example operator
+// configuration over vendor defaults:
+//
+//	fsys := libcni.OverlayFS(os.DirFS("/etc/cni/net.d"), defaults)
+//	list, err := libcni.LoadNetworkConfFS(fsys, ".", "mynet")
+//
+// Layers are given in order of decreasing precedence:
+//   - a file replaces any file or directory at the same path in later layers
+//   - a directory's contents are the union of the directories at that path
+//     in every layer, as long as it is not replaced by a file in an earlier
+//     layer
+//   - entries that do not exist in a layer are looked up in the next one
+//
+// Since configurations are found by listing directories, a network defined
+// by a file in a later layer can be overridden by a file of the same name in
+// an earlier layer, or by a file that sorts before it and defines the same
+// network name, as described for LoadNetworkConfigs.
+func OverlayFS(layers ...fs.FS) fs.FS {
+	return overlayFS(layers)
+}
+
+type overlayFS []fs.FS
+
+// resolve returns the layers providing name, in order of precedence, and
+// whether it is a directory. A file is always provided by a single layer.
+func (o overlayFS) resolve(op, name string) ([]fs.FS, bool, error) {
+	if !fs.ValidPath(name) {
+		return nil, false, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	candidates := []fs.FS(o)
+	if name != "." {
+		// Only layers where every parent is a visible directory count
+		parents, isDir, err := o.resolve(op, path.Dir(name))
+		if err != nil || !isDir {
+			return nil, false, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		candidates = parents
+	}
+
+	var found []fs.FS
+	isDir := false
+	for _, layer := range candidates {
+		info, err := fs.Stat(layer, name)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			continue
+		case err != nil:
+			return nil, false, err
+		}
+		if len(found) == 0 {
+			isDir = info.IsDir()
+			found = append(found, layer)
+			if !isDir {
+				break
+			}
+		} else if info.IsDir() {
+			found = append(found, layer)
+		}
+	}
+	if len(found) == 0 {
+		return nil, false, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return found, isDir, nil
+}
+
+func (o overlayFS) Open(name string) (fs.File, error) {
+	layers, isDir, err := o.resolve("open", name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := layers[0].Open(name)
+	if err != nil || !isDir || len(layers) == 1 {
+		return f, err
+	}
+	return &overlayDir{File: f, fsys: o, name: name}, nil
+}
+
+func (o overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	layers, isDir, err := o.resolve("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !isDir {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+
+	seen := map[string]bool{}
+	var entries []fs.DirEntry
+	for _, layer := range layers {
+		layerEntries, err := fs.ReadDir(layer, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range layerEntries {
+			if !seen[e.Name()] {
+				seen[e.Name()] = true
+				entries = append(entries, e)
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// overlayDir is a directory present in several layers. Its metadata comes
+// from the layer with the highest precedence.
+type overlayDir struct {
+	fs.File
+	fsys    overlayFS
+	name    string
+	entries []fs.DirEntry
+	read    bool
+}
+
+func (d *overlayDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.read {
+		entries, err := d.fsys.ReadDir(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.entries = entries
+		d.read = true
+	}
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(d.entries))
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
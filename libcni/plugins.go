@@ -0,0 +1,94 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"context"
+
+	"github.com/containernetworking/cni/pkg/invoke"
+)
+
+// PluginReport describes how a plugin name resolves in the search path
+type PluginReport struct {
+	Name string
+	// Candidates are the files that could provide the plugin, in search
+	// order. It is empty if the plugin was not found, in which case Err is
+	// set.
+	Candidates []*PluginCandidateReport
+	Err        error
+}
+
+// PluginCandidateReport is a plugin candidate with its VERSION output
+type PluginCandidateReport struct {
+	*invoke.PluginCandidate
+	// Versions are the CNI versions the candidate supports. They are only
+	// queried for executable candidates.
+	Versions   []string
+	VersionErr error
+}
+
+// Selected returns the candidate that is executed for the plugin, or nil if
+// the plugin was not found
+func (r *PluginReport) Selected() *PluginCandidateReport {
+	for _, c := range r.Candidates {
+		if c.Selected {
+			return c
+		}
+	}
+	return nil
+}
+
+// Shadowed returns true if more than one file provides the plugin
+func (r *PluginReport) Shadowed() bool {
+	return len(r.Candidates) > 1
+}
+
+// PluginReports resolves each named plugin in the CNIConfig's path and
+// reports every candidate file, which one is selected, and the versions each
+// supports. Without names, every plugin found in the path is reported.
+// Errors finding or probing individual plugins are recorded in the reports.
+func (c *CNIConfig) PluginReports(ctx context.Context, names ...string) ([]*PluginReport, error) {
+	if len(names) == 0 {
+		var err error
+		if names, err = invoke.ListPluginsInPath(c.Path); err != nil {
+			return nil, err
+		}
+	}
+
+	reports := make([]*PluginReport, 0, len(names))
+	for _, name := range names {
+		report := &PluginReport{Name: name}
+		reports = append(reports, report)
+
+		candidates, err := invoke.FindAllInPath(name, c.Path)
+		if err != nil {
+			report.Err = err
+			continue
+		}
+		for _, candidate := range candidates {
+			cr := &PluginCandidateReport{PluginCandidate: candidate}
+			if candidate.Executable {
+				vi, err := c.getVersionInfo(ctx, candidate.Path)
+				if err != nil {
+					cr.VersionErr = err
+				} else {
+					cr.Versions = vi.SupportedVersions()
+				}
+			}
+			report.Candidates = append(report.Candidates, cr)
+		}
+	}
+	return reports, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+var _ = Describe("Reporting plugins in the search path", func() {
+	var (
+		exec      *versionExec
+		overrides string
+		cniConfig *libcni.CNIConfig
+	)
+
+	BeforeEach(func() {
+		exec = newVersionExec(map[string][]string{
+			"bridge": {"0.4.0", "1.0.0"},
+			"ptp":    {"1.0.0"},
+		})
+		overrides = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(overrides, "bridge"), nil, 0o755)).To(Succeed())
+		cniConfig = libcni.NewCNIConfig([]string{overrides, exec.dir}, exec)
+	})
+
+	It("reports every plugin with its candidates", func() {
+		reports, err := cniConfig.PluginReports(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reports).To(HaveLen(2))
+
+		bridge := reports[0]
+		Expect(bridge.Name).To(Equal("bridge"))
+		Expect(bridge.Err).NotTo(HaveOccurred())
+		Expect(bridge.Shadowed()).To(BeTrue())
+		Expect(bridge.Candidates).To(HaveLen(2))
+		Expect(bridge.Selected().Path).To(Equal(filepath.Join(overrides, "bridge")))
+		Expect(bridge.Candidates[1].Path).To(Equal(filepath.Join(exec.dir, "bridge")))
+		Expect(bridge.Candidates[1].DirIndex).To(Equal(1))
+		Expect(bridge.Candidates[1].Selected).To(BeFalse())
+		for _, c := range bridge.Candidates {
+			Expect(c.VersionErr).NotTo(HaveOccurred())
+			Expect(c.Versions).To(Equal([]string{"0.4.0", "1.0.0"}))
+		}
+
+		ptp := reports[1]
+		Expect(ptp.Name).To(Equal("ptp"))
+		Expect(ptp.Shadowed()).To(BeFalse())
+		Expect(ptp.Selected().Versions).To(Equal([]string{"1.0.0"}))
+	})
+
+	It("reports missing plugins", func() {
+		reports, err := cniConfig.PluginReports(context.TODO(), "ptp", "macvlan")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reports).To(HaveLen(2))
+		Expect(reports[0].Selected()).NotTo(BeNil())
+		Expect(reports[1].Err).To(MatchError(ContainSubstring(`failed to find plugin "macvlan"`)))
+		Expect(reports[1].Selected()).To(BeNil())
+	})
+
+	It("does not probe candidates without execute permission", func() {
+		if runtime.GOOS == "windows" {
+			Skip("Windows has no execute permission bits")
+		}
+		Expect(os.Chmod(filepath.Join(overrides, "bridge"), 0o644)).To(Succeed())
+
+		reports, err := cniConfig.PluginReports(context.TODO(), "bridge")
+		Expect(err).NotTo(HaveOccurred())
+		selected := reports[0].Selected()
+		Expect(selected.Executable).To(BeFalse())
+		Expect(selected.Versions).To(BeEmpty())
+		Expect(exec.calls["bridge"]).To(Equal(1))
+	})
+})
@@ -0,0 +1,635 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed netconflist.schema.json
+var netConfListSchemaBytes []byte
+
+var netConfListSchema = mustCompileSchema(netConfListSchemaBytes)
+
+// NetworkConfigListSchema returns the JSON Schema (draft 2020-12) describing
+// network configuration lists. Plugin configurations are described by the
+// "#/$defs/plugin" definition.
+func NetworkConfigListSchema() []byte {
+	return append([]byte(nil), netConfListSchemaBytes...)
+}
+
+// SchemaError is a single violation of a configuration schema
+type SchemaError struct {
+	// Path locates the offending value, e.g. "plugins[1].ipam.type".
+	// It is empty for the document itself.
+	Path    string
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+var (
+	pluginSchemasLock sync.RWMutex
+	pluginSchemas     = map[string]*schemaNode{}
+)
+
+// RegisterPluginSchema registers a JSON Schema fragment that plugin
+// configurations of the given type must satisfy in addition to the generic
+// plugin schema. Fragments may use the type, enum, const, pattern, minLength,
+// maxLength, minimum, maximum, minItems, maxItems, items, properties,
+// required, additionalProperties, allOf, anyOf, oneOf and $ref ("#" or
+// "#/$defs/...") keywords; any other validation keyword is rejected so that
+// it is not silently ignored.
+func RegisterPluginSchema(pluginType string, schema []byte) error {
+	if pluginType == "" {
+		return fmt.Errorf("plugin type must not be empty")
+	}
+	node, err := compileSchema(schema)
+	if err != nil {
+		return fmt.Errorf("invalid schema for plugin %s: %w", pluginType, err)
+	}
+
+	pluginSchemasLock.Lock()
+	defer pluginSchemasLock.Unlock()
+	if _, ok := pluginSchemas[pluginType]; ok {
+		return fmt.Errorf("schema already registered for plugin %s", pluginType)
+	}
+	pluginSchemas[pluginType] = node
+	return nil
+}
+
+// ValidateNetworkConfigSchema checks configuration list bytes against the
+// network configuration list schema and the schemas registered for its
+// plugins. It returns every violation found, each as a *SchemaError, joined
+// with errors.Join.
+func ValidateNetworkConfigSchema(confBytes []byte) error {
+	doc, err := decodeSchemaDocument(confBytes)
+	if err != nil {
+		return err
+	}
+	errs := netConfListSchema.validate(doc, "")
+
+	if obj, ok := doc.(map[string]interface{}); ok {
+		if plugins, ok := obj["plugins"].([]interface{}); ok {
+			for i, plugin := range plugins {
+				errs = append(errs, validatePluginFragment(plugin, fmt.Sprintf("plugins[%d]", i))...)
+			}
+		}
+	}
+	return joinSchemaErrors(errs)
+}
+
+// ValidatePluginConfigSchema checks a single plugin configuration, such as
+// one loaded from <dir>/<network>/*.conf, against the generic plugin schema
+// and the schema registered for its type.
+func ValidatePluginConfigSchema(pluginConfBytes []byte) error {
+	doc, err := decodeSchemaDocument(pluginConfBytes)
+	if err != nil {
+		return err
+	}
+	errs := netConfListSchema.defs["plugin"].validate(doc, "")
+	errs = append(errs, validatePluginFragment(doc, "")...)
+	return joinSchemaErrors(errs)
+}
+
+// listSchemaErrors checks a loaded configuration list, including plugins
+// loaded from separate files, and returns the violations of the list itself
+// and of each plugin.
+func listSchemaErrors(list *NetworkConfigList) ([]*SchemaError, [][]*SchemaError, error) {
+	doc, err := decodeSchemaDocument(list.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The plugins are checked below, since not all of them need to be
+	// inlined in the list.
+	if obj, ok := doc.(map[string]interface{}); ok {
+		delete(obj, "plugins")
+	}
+	listErrs := netConfListSchema.validate(doc, "")
+
+	pluginErrs := make([][]*SchemaError, len(list.Plugins))
+	for i, plugin := range list.Plugins {
+		pluginDoc, err := decodeSchemaDocument(plugin.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		path := fmt.Sprintf("plugins[%d]", i)
+		pluginErrs[i] = append(netConfListSchema.defs["plugin"].validate(pluginDoc, path),
+			validatePluginFragment(pluginDoc, path)...)
+	}
+	return listErrs, pluginErrs, nil
+}
+
+func validatePluginFragment(plugin interface{}, path string) []*SchemaError {
+	obj, ok := plugin.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	pluginType, ok := obj["type"].(string)
+	if !ok {
+		return nil
+	}
+
+	pluginSchemasLock.RLock()
+	node := pluginSchemas[pluginType]
+	pluginSchemasLock.RUnlock()
+	if node == nil {
+		return nil
+	}
+	return node.validate(plugin, path)
+}
+
+func decodeSchemaDocument(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("error parsing configuration: %w", err)
+	}
+	return doc, nil
+}
+
+func joinSchemaErrors(schemaErrs []*SchemaError) error {
+	errs := make([]error, 0, len(schemaErrs))
+	for _, e := range schemaErrs {
+		errs = append(errs, e)
+	}
+	return errors.Join(errs...)
+}
+
+// schemaNode is a compiled subset of JSON Schema
+type schemaNode struct {
+	// always is set for the boolean schemas true and false
+	always *bool
+
+	ref      string
+	resolved *schemaNode
+
+	types      []string
+	enum       []interface{}
+	hasConst   bool
+	constValue interface{}
+	pattern    *regexp.Regexp
+
+	minLength, maxLength *int
+	minItems, maxItems   *int
+	minimum, maximum     *float64
+
+	properties map[string]*schemaNode
+	required   []string
+	additional *schemaNode
+	items      *schemaNode
+
+	allOf, anyOf, oneOf []*schemaNode
+
+	// defs is only set on the root node
+	defs map[string]*schemaNode
+}
+
+// annotationKeywords carry no validation semantics and are accepted as-is
+var annotationKeywords = map[string]bool{
+	"$schema":     true,
+	"$id":         true,
+	"$comment":    true,
+	"$defs":       true,
+	"title":       true,
+	"description": true,
+	"default":     true,
+	"examples":    true,
+	"deprecated":  true,
+	"readOnly":    true,
+	"writeOnly":   true,
+}
+
+func mustCompileSchema(data []byte) *schemaNode {
+	node, err := compileSchema(data)
+	if err != nil {
+		panic(err.Error())
+	}
+	return node
+}
+
+func compileSchema(data []byte) (*schemaNode, error) {
+	raw, err := decodeSchemaDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	c := &schemaCompiler{}
+	root, err := c.compile(raw, "#")
+	if err != nil {
+		return nil, err
+	}
+
+	root.defs = map[string]*schemaNode{}
+	if obj, ok := raw.(map[string]interface{}); ok {
+		if rawDefs, ok := obj["$defs"]; ok {
+			defs, ok := rawDefs.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("#/$defs: must be an object")
+			}
+			for name, def := range defs {
+				if root.defs[name], err = c.compile(def, "#/$defs/"+name); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for _, n := range c.refs {
+		switch {
+		case n.ref == "#":
+			n.resolved = root
+		case strings.HasPrefix(n.ref, "#/$defs/"):
+			n.resolved = root.defs[strings.TrimPrefix(n.ref, "#/$defs/")]
+		}
+		if n.resolved == nil {
+			return nil, fmt.Errorf("unresolvable $ref %q", n.ref)
+		}
+	}
+	return root, nil
+}
+
+type schemaCompiler struct {
+	refs []*schemaNode
+}
+
+func (c *schemaCompiler) compile(raw interface{}, loc string) (*schemaNode, error) {
+	if b, ok := raw.(bool); ok {
+		return &schemaNode{always: &b}, nil
+	}
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: schema must be an object or boolean", loc)
+	}
+
+	n := &schemaNode{}
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := obj[k]
+		kloc := loc + "/" + k
+		var err error
+		switch k {
+		case "$ref":
+			ref, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: must be a string", kloc)
+			}
+			n.ref = ref
+			c.refs = append(c.refs, n)
+		case "type":
+			switch t := v.(type) {
+			case string:
+				n.types = []string{t}
+			case []interface{}:
+				for _, item := range t {
+					s, ok := item.(string)
+					if !ok {
+						return nil, fmt.Errorf("%s: must be a string or list of strings", kloc)
+					}
+					n.types = append(n.types, s)
+				}
+			default:
+				return nil, fmt.Errorf("%s: must be a string or list of strings", kloc)
+			}
+			for _, t := range n.types {
+				switch t {
+				case "null", "boolean", "object", "array", "number", "integer", "string":
+				default:
+					return nil, fmt.Errorf("%s: unknown type %q", kloc, t)
+				}
+			}
+		case "enum":
+			if n.enum, ok = v.([]interface{}); !ok {
+				return nil, fmt.Errorf("%s: must be a list", kloc)
+			}
+		case "const":
+			n.hasConst = true
+			n.constValue = v
+		case "pattern":
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: must be a string", kloc)
+			}
+			if n.pattern, err = regexp.Compile(s); err != nil {
+				return nil, fmt.Errorf("%s: %w", kloc, err)
+			}
+		case "minLength":
+			n.minLength, err = schemaInt(v, kloc)
+		case "maxLength":
+			n.maxLength, err = schemaInt(v, kloc)
+		case "minItems":
+			n.minItems, err = schemaInt(v, kloc)
+		case "maxItems":
+			n.maxItems, err = schemaInt(v, kloc)
+		case "minimum":
+			n.minimum, err = schemaFloat(v, kloc)
+		case "maximum":
+			n.maximum, err = schemaFloat(v, kloc)
+		case "properties":
+			props, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: must be an object", kloc)
+			}
+			n.properties = make(map[string]*schemaNode, len(props))
+			for name, prop := range props {
+				if n.properties[name], err = c.compile(prop, kloc+"/"+name); err != nil {
+					return nil, err
+				}
+			}
+		case "required":
+			list, ok := v.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: must be a list of strings", kloc)
+			}
+			for _, item := range list {
+				s, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("%s: must be a list of strings", kloc)
+				}
+				n.required = append(n.required, s)
+			}
+		case "additionalProperties":
+			n.additional, err = c.compile(v, kloc)
+		case "items":
+			n.items, err = c.compile(v, kloc)
+		case "allOf":
+			n.allOf, err = c.compileList(v, kloc)
+		case "anyOf":
+			n.anyOf, err = c.compileList(v, kloc)
+		case "oneOf":
+			n.oneOf, err = c.compileList(v, kloc)
+		default:
+			if !annotationKeywords[k] {
+				return nil, fmt.Errorf("%s: unsupported keyword", kloc)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+func (c *schemaCompiler) compileList(raw interface{}, loc string) ([]*schemaNode, error) {
+	list, ok := raw.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("%s: must be a non-empty list of schemas", loc)
+	}
+	nodes := make([]*schemaNode, 0, len(list))
+	for i, item := range list {
+		n, err := c.compile(item, fmt.Sprintf("%s/%d", loc, i))
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+func schemaInt(v interface{}, loc string) (*int, error) {
+	num, ok := v.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("%s: must be a non-negative integer", loc)
+	}
+	i, err := num.Int64()
+	if err != nil || i < 0 {
+		return nil, fmt.Errorf("%s: must be a non-negative integer", loc)
+	}
+	n := int(i)
+	return &n, nil
+}
+
+func schemaFloat(v interface{}, loc string) (*float64, error) {
+	num, ok := v.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("%s: must be a number", loc)
+	}
+	f, err := num.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("%s: must be a number", loc)
+	}
+	return &f, nil
+}
+
+func jsonType(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		// Numbers without a fractional part, such as 1.0, are integers
+		if _, err := val.Int64(); err == nil {
+			return "integer"
+		}
+		if f, err := val.Float64(); err == nil && f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func childPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func (n *schemaNode) validate(v interface{}, path string) []*SchemaError {
+	if n.always != nil {
+		if *n.always {
+			return nil
+		}
+		return []*SchemaError{{Path: path, Message: "no value is allowed here"}}
+	}
+
+	var errs []*SchemaError
+	if n.resolved != nil {
+		// $ref applies in addition to the other keywords next to it
+		errs = n.resolved.validate(v, path)
+	}
+	failure := func(format string, args ...interface{}) *SchemaError {
+		return &SchemaError{Path: path, Message: fmt.Sprintf(format, args...)}
+	}
+	// fail stops at a violation that makes the remaining keywords moot
+	fail := func(format string, args ...interface{}) []*SchemaError {
+		return append(errs, failure(format, args...))
+	}
+
+	if len(n.types) > 0 {
+		actual := jsonType(v)
+		ok := false
+		for _, t := range n.types {
+			if t == actual || (t == "number" && actual == "integer") {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return fail("expected %s, got %s", strings.Join(n.types, " or "), actual)
+		}
+	}
+
+	if n.hasConst && !jsonEqual(v, n.constValue) {
+		return fail("must be %s", jsonString(n.constValue))
+	}
+	if n.enum != nil {
+		found := false
+		for _, e := range n.enum {
+			if jsonEqual(v, e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			allowed := make([]string, 0, len(n.enum))
+			for _, e := range n.enum {
+				allowed = append(allowed, jsonString(e))
+			}
+			return fail("must be one of %s", strings.Join(allowed, ", "))
+		}
+	}
+
+	switch val := v.(type) {
+	case string:
+		length := len([]rune(val))
+		if n.minLength != nil && length < *n.minLength {
+			errs = append(errs, failure("must be at least %d characters long", *n.minLength))
+		}
+		if n.maxLength != nil && length > *n.maxLength {
+			errs = append(errs, failure("must be at most %d characters long", *n.maxLength))
+		}
+		if n.pattern != nil && !n.pattern.MatchString(val) {
+			errs = append(errs, failure("%q does not match pattern %q", val, n.pattern.String()))
+		}
+	case json.Number:
+		f, _ := val.Float64()
+		if n.minimum != nil && f < *n.minimum {
+			errs = append(errs, failure("must be at least %v", *n.minimum))
+		}
+		if n.maximum != nil && f > *n.maximum {
+			errs = append(errs, failure("must be at most %v", *n.maximum))
+		}
+	case []interface{}:
+		if n.minItems != nil && len(val) < *n.minItems {
+			errs = append(errs, failure("must have at least %d items", *n.minItems))
+		}
+		if n.maxItems != nil && len(val) > *n.maxItems {
+			errs = append(errs, failure("must have at most %d items", *n.maxItems))
+		}
+		if n.items != nil {
+			for i, item := range val {
+				errs = append(errs, n.items.validate(item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case map[string]interface{}:
+		for _, req := range n.required {
+			if _, ok := val[req]; !ok {
+				errs = append(errs, &SchemaError{Path: childPath(path, req), Message: "is required"})
+			}
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if prop, ok := n.properties[k]; ok {
+				errs = append(errs, prop.validate(val[k], childPath(path, k))...)
+			} else if n.additional != nil {
+				if n.additional.always != nil && !*n.additional.always {
+					errs = append(errs, &SchemaError{Path: childPath(path, k), Message: "is not allowed"})
+					continue
+				}
+				errs = append(errs, n.additional.validate(val[k], childPath(path, k))...)
+			}
+		}
+	}
+
+	for _, sub := range n.allOf {
+		errs = append(errs, sub.validate(v, path)...)
+	}
+	if n.anyOf != nil {
+		matched := false
+		for _, sub := range n.anyOf {
+			if len(sub.validate(v, path)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			errs = append(errs, failure("does not match any allowed form"))
+		}
+	}
+	if n.oneOf != nil {
+		matches := 0
+		for _, sub := range n.oneOf {
+			if len(sub.validate(v, path)) == 0 {
+				matches++
+			}
+		}
+		if matches != 1 {
+			errs = append(errs, failure("must match exactly one allowed form, matched %d", matches))
+		}
+	}
+	return errs
+}
+
+func jsonEqual(a, b interface{}) bool {
+	if na, ok := a.(json.Number); ok {
+		if nb, ok := b.(json.Number); ok {
+			fa, errA := na.Float64()
+			fb, errB := nb.Float64()
+			return errA == nil && errB == nil && fa == fb
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func jsonString(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
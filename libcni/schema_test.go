@@ -0,0 +1,176 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni_test
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+// schemaTestPlugin is registered once for the whole suite, since plugin
+// schemas cannot be unregistered.
+var registerSchemaTestPlugin = sync.OnceValue(func() error {
+	return libcni.RegisterPluginSchema("schema-test", []byte(`{
+		"type": "object",
+		"required": ["bridge"],
+		"properties": {
+			"bridge": {"type": "string", "maxLength": 15},
+			"mtu": {"type": "integer", "minimum": 68, "maximum": 65535},
+			"mode": {"enum": ["l2", "l3"]},
+			"ranges": {"type": "array", "items": {"$ref": "#/$defs/range"}},
+			"primary": {"$ref": "#/$defs/range", "required": ["gateway"]}
+		},
+		"$defs": {
+			"range": {"type": "object", "required": ["subnet"]}
+		}
+	}`))
+})
+
+var _ = Describe("Configuration schema", func() {
+	schemaErrors := func(err error) []string {
+		var out []string
+		for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+			var schemaErr *libcni.SchemaError
+			Expect(errors.As(e, &schemaErr)).To(BeTrue())
+			out = append(out, schemaErr.Error())
+		}
+		return out
+	}
+
+	It("is valid JSON", func() {
+		var schema map[string]interface{}
+		Expect(json.Unmarshal(libcni.NetworkConfigListSchema(), &schema)).To(Succeed())
+		Expect(schema).To(HaveKey("$defs"))
+	})
+
+	It("accepts a valid configuration list", func() {
+		Expect(libcni.ValidateNetworkConfigSchema([]byte(`{
+			"cniVersion": "1.0.0",
+			"cniVersions": ["0.4.0", "1.0.0"],
+			"name": "test",
+			"disableCheck": "True",
+			"disableGC": false,
+			"loadOnlyInlinedPlugins": true,
+			"plugins": [{
+				"type": "bridge",
+				"capabilities": {"portMappings": true},
+				"ipam": {"type": "host-local", "subnet": "10.0.0.0/8"},
+				"dns": {"nameservers": ["10.0.0.1"]},
+				"someOption": 3
+			}]
+		}`))).To(Succeed())
+	})
+
+	It("reports every violation with its path", func() {
+		err := libcni.ValidateNetworkConfigSchema([]byte(`{
+			"cniVersion": "one",
+			"cniVersions": ["1.0.0", 4],
+			"disableCheck": "yes",
+			"plugins": [
+				{"type": "bridge", "capabilities": {"portMappings": "true"}},
+				{"ipam": {"subnet": "10.0.0.0/8"}, "dns": {"search": "example.com"}}
+			]
+		}`))
+		Expect(schemaErrors(err)).To(Equal([]string{
+			"name: is required",
+			`cniVersion: "one" does not match pattern "^[0-9]+\\.[0-9]+\\.[0-9]+$"`,
+			"cniVersions[1]: expected string, got integer",
+			"disableCheck: does not match any allowed form",
+			"plugins[0].capabilities.portMappings: expected boolean, got string",
+			"plugins[1].type: is required",
+			"plugins[1].dns.search: expected array, got string",
+			"plugins[1].ipam.type: is required",
+		}))
+	})
+
+	It("rejects empty plugin lists", func() {
+		err := libcni.ValidateNetworkConfigSchema([]byte(`{"name": "test", "plugins": []}`))
+		Expect(err).To(MatchError("plugins: must have at least 1 items"))
+	})
+
+	It("reports unparseable input", func() {
+		err := libcni.ValidateNetworkConfigSchema([]byte(`{"name": `))
+		Expect(err).To(MatchError(HavePrefix("error parsing configuration")))
+	})
+
+	Context("with a registered plugin schema", func() {
+		BeforeEach(func() {
+			Expect(registerSchemaTestPlugin()).To(Succeed())
+		})
+
+		It("applies the fragment to plugins of that type", func() {
+			err := libcni.ValidateNetworkConfigSchema([]byte(`{"name": "test", "plugins": [
+				{"type": "bridge"},
+				{"type": "schema-test", "bridge": "a-very-long-bridge-name", "mtu": 10, "mode": "l4", "ranges": [{}]}
+			]}`))
+			Expect(schemaErrors(err)).To(Equal([]string{
+				"plugins[1].bridge: must be at most 15 characters long",
+				`plugins[1].mode: must be one of "l2", "l3"`,
+				"plugins[1].mtu: must be at least 68",
+				"plugins[1].ranges[0].subnet: is required",
+			}))
+		})
+
+		It("applies the keywords next to a $ref as well", func() {
+			err := libcni.ValidatePluginConfigSchema([]byte(`{"type": "schema-test", "bridge": "br0", "primary": {}}`))
+			Expect(schemaErrors(err)).To(Equal([]string{
+				"primary.subnet: is required",
+				"primary.gateway: is required",
+			}))
+
+			err = libcni.ValidatePluginConfigSchema([]byte(`{"type": "schema-test", "bridge": "br0", "primary": []}`))
+			Expect(schemaErrors(err)).To(Equal([]string{
+				"primary: expected object, got array",
+			}))
+		})
+
+		It("treats numbers without a fractional part as integers", func() {
+			Expect(libcni.ValidatePluginConfigSchema([]byte(`{"type": "schema-test", "bridge": "br0", "mtu": 1500.0}`))).To(Succeed())
+			Expect(libcni.ValidatePluginConfigSchema([]byte(`{"type": "schema-test", "bridge": "br0", "mtu": 1.5e3}`))).To(Succeed())
+
+			err := libcni.ValidatePluginConfigSchema([]byte(`{"type": "schema-test", "bridge": "br0", "mtu": 1500.5}`))
+			Expect(err).To(MatchError("mtu: expected integer, got number"))
+		})
+
+		It("applies the fragment to single plugin configurations", func() {
+			err := libcni.ValidatePluginConfigSchema([]byte(`{"type": "schema-test"}`))
+			Expect(err).To(MatchError("bridge: is required"))
+
+			Expect(libcni.ValidatePluginConfigSchema([]byte(`{"type": "schema-test", "bridge": "br0", "mtu": 1500}`))).To(Succeed())
+		})
+
+		It("refuses to register a second schema for the type", func() {
+			err := libcni.RegisterPluginSchema("schema-test", []byte(`{}`))
+			Expect(err).To(MatchError("schema already registered for plugin schema-test"))
+		})
+	})
+
+	It("rejects fragments it cannot enforce", func() {
+		err := libcni.RegisterPluginSchema("unsupported", []byte(`{"properties": {"a": {"format": "ipv4"}}}`))
+		Expect(err).To(MatchError("invalid schema for plugin unsupported: #/properties/a/format: unsupported keyword"))
+
+		err = libcni.RegisterPluginSchema("unsupported", []byte(`{"$ref": "#/$defs/missing"}`))
+		Expect(err).To(MatchError(`invalid schema for plugin unsupported: unresolvable $ref "#/$defs/missing"`))
+
+		err = libcni.RegisterPluginSchema("unsupported", []byte(`{"pattern": "("}`))
+		Expect(err).To(MatchError(ContainSubstring("#/pattern")))
+	})
+})
@@ -0,0 +1,81 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/containernetworking/cni/pkg/invoke"
+	"github.com/containernetworking/cni/pkg/version"
+)
+
+// A plugin configuration setting the "cni.dev/socket" key to the absolute
+// path of a Unix socket is not executed: its requests are sent to the
+// daemon listening on the socket instead, see skel.Server. For example
+//
+//	{"type": "bridge", "cni.dev/socket": "/run/cni/bridge.sock"}
+//
+// The key is removed from the configuration passed to the plugin, and kept
+// in PluginConfig.Socket.
+const pluginSocketKey = "cni.dev/socket"
+
+// pluginSocket returns the value of the plugin's "cni.dev/socket" key, and
+// the configuration without it
+func pluginSocket(data []byte) (string, []byte, error) {
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return "", data, nil
+	}
+	rawSocket, ok := raw[pluginSocketKey]
+	if !ok {
+		return "", data, nil
+	}
+	var socket string
+	if err := json.Unmarshal(rawSocket, &socket); err != nil || !filepath.IsAbs(socket) {
+		return "", nil, fmt.Errorf("invalid %s %s: must be an absolute path", pluginSocketKey, rawSocket)
+	}
+	return socket, removeKeys(data, pluginSocketKey), nil
+}
+
+// findPlugin returns the Exec that runs the plugin and the path to pass it:
+// a SocketExec for plugins with a socket, otherwise c's Exec
+func (c *CNIConfig) findPlugin(net *PluginConfig) (invoke.Exec, string, error) {
+	exec := c.ensureExec()
+	if net.Socket != "" {
+		exec = &invoke.SocketExec{
+			Sockets: map[string]string{net.Network.Type: net.Socket},
+			Stderr:  os.Stderr,
+		}
+	}
+	pluginPath, err := exec.FindInPath(net.Network.Type, c.Path)
+	if err != nil {
+		return nil, "", err
+	}
+	return exec, pluginPath, nil
+}
+
+// pluginVersionInfo returns the version info of a plugin found by
+// findPlugin. There is no binary identifying a plugin served on a socket,
+// so only executed plugins are cached.
+func (c *CNIConfig) pluginVersionInfo(ctx context.Context, net *PluginConfig, exec invoke.Exec, pluginPath string) (version.PluginInfo, error) {
+	if net.Socket != "" {
+		return invoke.GetVersionInfo(ctx, pluginPath, exec)
+	}
+	return c.getVersionInfo(ctx, pluginPath)
+}
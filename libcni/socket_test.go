@@ -0,0 +1,123 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/containernetworking/cni/pkg/skel"
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/cni/pkg/version"
+)
+
+var _ = Describe("Plugins served on sockets", func() {
+	var (
+		socketPath string
+		received   chan *skel.CmdArgs
+		list       *libcni.NetworkConfigList
+		cniConfig  *libcni.CNIConfig
+		rt         *libcni.RuntimeConf
+		ctx        context.Context
+	)
+
+	BeforeEach(func() {
+		dir, err := os.MkdirTemp("", "cni-sock")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		socketPath = filepath.Join(dir, "shim.sock")
+
+		received = make(chan *skel.CmdArgs, 1)
+		server := &skel.Server{
+			Funcs: skel.CNIFuncs{
+				Add: func(args *skel.CmdArgs) error {
+					received <- args
+					return args.PrintResult(&current.Result{CNIVersion: "1.0.0", Interfaces: []*current.Interface{{Name: "eth0"}}}, "1.0.0")
+				},
+			},
+			VersionInfo: version.PluginSupports("1.0.0"),
+		}
+		l, err := net.Listen("unix", socketPath)
+		Expect(err).NotTo(HaveOccurred())
+		go func() {
+			defer GinkgoRecover()
+			Expect(server.Serve(l)).To(Equal(skel.ErrServerClosed))
+		}()
+		DeferCleanup(server.Close)
+
+		list, err = libcni.NetworkConfFromBytes([]byte(fmt.Sprintf(`{
+			"cniVersion": "1.0.0",
+			"name": "net",
+			"plugins": [{"type": "shim", "cni.dev/socket": %q, "mtu": 1450}]
+		}`, socketPath)))
+		Expect(err).NotTo(HaveOccurred())
+
+		// The plugin is not in the path, so it can only be reached through
+		// its socket
+		cniConfig = libcni.NewCNIConfig([]string{dir}, nil)
+		rt = &libcni.RuntimeConf{
+			ContainerID: "some-container-id",
+			NetNS:       "/some/netns",
+			IfName:      "eth0",
+			CacheDir:    dir,
+		}
+		ctx = context.TODO()
+	})
+
+	It("takes the socket from the plugin configuration", func() {
+		Expect(list.Plugins[0].Socket).To(Equal(socketPath))
+		Expect(list.Plugins[0].Bytes).To(MatchJSON(`{"type": "shim", "mtu": 1450}`))
+
+		injected, err := libcni.InjectConf(list.Plugins[0], map[string]interface{}{"name": "net"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(injected.Socket).To(Equal(socketPath))
+	})
+
+	It("sends requests to the socket instead of executing the plugin", func() {
+		result, err := cniConfig.AddNetworkList(ctx, list, rt)
+		Expect(err).NotTo(HaveOccurred())
+		res, err := current.NewResultFromResult(result)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Interfaces[0].Name).To(Equal("eth0"))
+
+		var args *skel.CmdArgs
+		Expect(received).To(Receive(&args))
+		Expect(args.ContainerID).To(Equal("some-container-id"))
+		Expect(args.StdinData).To(MatchJSON(`{"cniVersion": "1.0.0", "name": "net", "type": "shim", "mtu": 1450}`))
+	})
+
+	It("asks the socket for the plugin's versions", func() {
+		v, err := cniConfig.NegotiateNetworkListVersion(ctx, list)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal("1.0.0"))
+
+		_, err = cniConfig.ValidateNetworkList(ctx, list)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects sockets that are not absolute paths", func() {
+		for _, socket := range []string{`"shim.sock"`, `""`, `1`} {
+			_, err := libcni.NetworkPluginConfFromBytes([]byte(`{"type": "shim", "cni.dev/socket": ` + socket + `}`))
+			Expect(err).To(MatchError("error parsing configuration: invalid cni.dev/socket " + socket + ": must be an absolute path"))
+		}
+	})
+})
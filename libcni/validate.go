@@ -0,0 +1,332 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/containernetworking/cni/pkg/invoke"
+	"github.com/containernetworking/cni/pkg/utils"
+)
+
+// Severity is the importance of a ValidationFinding
+type Severity int
+
+const (
+	// SeverityInfo findings are worth knowing about but need no action
+	SeverityInfo Severity = iota
+	// SeverityWarning findings do not prevent the network from working but
+	// are likely mistakes
+	SeverityWarning
+	// SeverityError findings prevent the network from working
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// FindingKind classifies a ValidationFinding
+type FindingKind string
+
+const (
+	FindingInvalidNetworkName   FindingKind = "InvalidNetworkName"
+	FindingMissingPlugin        FindingKind = "MissingPlugin"
+	FindingUnsupportedVersion   FindingKind = "UnsupportedVersion"
+	FindingPluginError          FindingKind = "PluginError"
+	FindingDuplicatePluginType  FindingKind = "DuplicatePluginType"
+	FindingMissingIPAMType      FindingKind = "MissingIPAMType"
+	FindingDisabledCapability   FindingKind = "DisabledCapability"
+	FindingUnconsumedCapability FindingKind = "UnconsumedCapability"
+	FindingPluginRejected       FindingKind = "PluginRejected"
+	FindingValidateUnsupported  FindingKind = "ValidateUnsupported"
+	FindingSchemaViolation      FindingKind = "SchemaViolation"
+	FindingNoCommonVersion      FindingKind = "NoCommonVersion"
+)
+
+// ValidationFinding is a single problem found while validating a network
+// configuration list. It implements error so that findings can be joined
+// and later extracted with errors.As.
+type ValidationFinding struct {
+	Severity Severity    `json:"severity"`
+	Kind     FindingKind `json:"kind"`
+	// Plugin is the index of the plugin in the list, or -1 if the finding
+	// concerns the list itself
+	Plugin     int    `json:"plugin"`
+	PluginType string `json:"pluginType,omitempty"`
+	Message    string `json:"message"`
+	// Err is the underlying error, if any
+	Err error `json:"-"`
+}
+
+func (f *ValidationFinding) Error() string {
+	return f.Message
+}
+
+func (f *ValidationFinding) Unwrap() error {
+	return f.Err
+}
+
+// ValidationResult is the outcome of ValidateNetworkListDetailed
+type ValidationResult struct {
+	// CNIVersion is the version the plugins were checked against
+	CNIVersion string `json:"cniVersion"`
+	// Capabilities lists all capabilities enabled by the configuration
+	Capabilities []string `json:"capabilities"`
+	// Findings lists problems in the order they were found
+	Findings []*ValidationFinding `json:"findings,omitempty"`
+}
+
+// Err returns all findings of SeverityError joined with errors.Join, or nil
+// if there are none.
+func (r *ValidationResult) Err() error {
+	var errs []error
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			errs = append(errs, f)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FindingsAtLeast returns the findings of at least the given severity
+func (r *ValidationResult) FindingsAtLeast(s Severity) []*ValidationFinding {
+	var out []*ValidationFinding
+	for _, f := range r.Findings {
+		if f.Severity >= s {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func (r *ValidationResult) add(severity Severity, kind FindingKind, plugin int, pluginType string, err error) {
+	r.Findings = append(r.Findings, &ValidationFinding{
+		Severity:   severity,
+		Kind:       kind,
+		Plugin:     plugin,
+		PluginType: pluginType,
+		Message:    err.Error(),
+		Err:        err,
+	})
+}
+
+// ValidateOptions changes what ValidateNetworkListDetailed checks
+type ValidateOptions struct {
+	// CallPlugins sends the experimental VALIDATE command to every plugin
+	// that exists and supports the list's version, so that plugins can
+	// reject configuration they would otherwise only fail on during ADD.
+	CallPlugins bool
+	// CheckSchema checks the list and every plugin against the network
+	// configuration list schema and any registered plugin schemas.
+	CheckSchema bool
+	// RuntimeCapabilities lists the capabilities the runtime passes
+	// arguments for. If set, capabilities that a plugin enables but that
+	// are not in the list are reported, since the plugin will never receive
+	// them.
+	RuntimeCapabilities []string
+	// NegotiateVersion checks the plugins against the highest version
+	// supported by all of them, as returned by NegotiateNetworkListVersion,
+	// rather than against the list's CNIVersion. The list is not modified.
+	NegotiateVersion bool
+}
+
+// ValidateNetworkListDetailed checks a configuration list and reports every
+// problem found, rather than only whether the list is usable:
+//   - the network name is valid
+//   - all the specified plugins exist on disk and support the desired version
+//   - no plugin type is listed more than once
+//   - every "ipam" section names its type
+//   - declared capabilities are enabled and, if opts lists the capabilities
+//     of the runtime, consumed by it
+//   - if requested in opts, the configuration matches its schema, the plugins
+//     share a version and every plugin accepts its configuration
+//
+// The network name, "ipam" and schema checks only report warnings, so that
+// ValidateNetworkList keeps accepting the configurations it accepted before
+// they were added.
+//
+// opts may be nil. Errors are only returned through the result's findings.
+func (c *CNIConfig) ValidateNetworkListDetailed(ctx context.Context, list *NetworkConfigList, opts *ValidateOptions) *ValidationResult {
+	if opts == nil {
+		opts = &ValidateOptions{}
+	}
+	res := &ValidationResult{Capabilities: []string{}}
+
+	if err := utils.ValidateNetworkName(list.Name); err != nil {
+		res.add(SeverityWarning, FindingInvalidNetworkName, -1, "",
+			fmt.Errorf("invalid network name %q: %w", list.Name, err))
+	}
+
+	var pluginSchemaErrs [][]*SchemaError
+	if opts.CheckSchema {
+		listErrs, pluginErrs, err := listSchemaErrors(list)
+		if err != nil {
+			res.add(SeverityWarning, FindingSchemaViolation, -1, "", err)
+		}
+		for _, e := range listErrs {
+			res.add(SeverityWarning, FindingSchemaViolation, -1, "", e)
+		}
+		pluginSchemaErrs = pluginErrs
+	}
+
+	res.CNIVersion = list.CNIVersion
+	if opts.NegotiateVersion {
+		// Missing plugins are reported below, so only report the lack of
+		// a common version here.
+		var ncvErr *NoCommonVersionError
+		v, err := c.NegotiateNetworkListVersion(ctx, list)
+		switch {
+		case err == nil:
+			res.CNIVersion = v
+		case errors.As(err, &ncvErr):
+			res.add(SeverityError, FindingNoCommonVersion, -1, "", err)
+		}
+	}
+
+	caps := map[string]bool{}
+	seenTypes := map[string]int{}
+	for i, net := range list.Plugins {
+		pluginType := net.Network.Type
+
+		if first, ok := seenTypes[pluginType]; ok {
+			res.add(SeverityWarning, FindingDuplicatePluginType, i, pluginType,
+				fmt.Errorf("plugin %s is listed more than once (first at index %d)", pluginType, first))
+		} else {
+			seenTypes[pluginType] = i
+		}
+		if pluginSchemaErrs != nil {
+			for _, e := range pluginSchemaErrs[i] {
+				res.add(SeverityWarning, FindingSchemaViolation, i, pluginType, e)
+			}
+		}
+
+		exec, pluginPath, ok := c.validatePluginFindings(ctx, res, i, net, res.CNIVersion)
+		if ok && opts.CallPlugins {
+			c.callValidate(ctx, res, i, exec, pluginPath, list.Name, res.CNIVersion, net)
+		}
+
+		if missingIPAMType(net) {
+			res.add(SeverityWarning, FindingMissingIPAMType, i, pluginType,
+				fmt.Errorf("plugin %s has an ipam section without a type", pluginType))
+		}
+
+		capNames := make([]string, 0, len(net.Network.Capabilities))
+		for capability := range net.Network.Capabilities {
+			capNames = append(capNames, capability)
+		}
+		sort.Strings(capNames)
+		for _, capability := range capNames {
+			if !net.Network.Capabilities[capability] {
+				res.add(SeverityInfo, FindingDisabledCapability, i, pluginType,
+					fmt.Errorf("plugin %s declares capability %q but disables it, so it will not be passed", pluginType, capability))
+				continue
+			}
+			if opts.RuntimeCapabilities != nil && !slices.Contains(opts.RuntimeCapabilities, capability) {
+				res.add(SeverityWarning, FindingUnconsumedCapability, i, pluginType,
+					fmt.Errorf("plugin %s declares capability %q, which the runtime does not pass", pluginType, capability))
+			}
+			caps[capability] = true
+		}
+	}
+
+	for capability := range caps {
+		res.Capabilities = append(res.Capabilities, capability)
+	}
+	sort.Strings(res.Capabilities)
+
+	return res
+}
+
+// validatePluginFindings records whether the plugin exists and supports the
+// expected version, and returns its Exec and path if both are true.
+func (c *CNIConfig) validatePluginFindings(ctx context.Context, res *ValidationResult, index int, net *PluginConfig, expectedVersion string) (invoke.Exec, string, bool) {
+	pluginType := net.Network.Type
+	exec, pluginPath, err := c.findPlugin(net)
+	if err != nil {
+		res.add(SeverityError, FindingMissingPlugin, index, pluginType, err)
+		return nil, "", false
+	}
+	if expectedVersion == "" {
+		expectedVersion = "0.1.0"
+	}
+
+	vi, err := c.pluginVersionInfo(ctx, net, exec, pluginPath)
+	if err != nil {
+		res.add(SeverityError, FindingPluginError, index, pluginType, err)
+		return nil, "", false
+	}
+	for _, vers := range vi.SupportedVersions() {
+		if vers == expectedVersion {
+			return exec, pluginPath, true
+		}
+	}
+	res.add(SeverityError, FindingUnsupportedVersion, index, pluginType,
+		fmt.Errorf("plugin %s does not support config version %q", pluginType, expectedVersion))
+	return nil, "", false
+}
+
+// callValidate sends the plugin its configuration, as it would receive it
+// during ADD, with the VALIDATE command.
+func (c *CNIConfig) callValidate(ctx context.Context, res *ValidationResult, index int, exec invoke.Exec, pluginPath, name, cniVersion string, net *PluginConfig) {
+	pluginType := net.Network.Type
+	conf, err := buildOneConfig(name, cniVersion, net, nil, nil)
+	if err != nil {
+		res.add(SeverityError, FindingPluginError, index, pluginType, err)
+		return
+	}
+
+	err = invoke.ExecPluginWithoutResult(ctx, pluginPath, conf.Bytes, c.args("VALIDATE", &RuntimeConf{}), exec)
+	switch {
+	case err == nil:
+	case invoke.ValidateUnsupported(err):
+		res.add(SeverityInfo, FindingValidateUnsupported, index, pluginType,
+			fmt.Errorf("plugin %s does not implement VALIDATE", pluginType))
+	default:
+		res.add(SeverityError, FindingPluginRejected, index, pluginType,
+			fmt.Errorf("plugin %s rejected its configuration: %w", pluginType, err))
+	}
+}
+
+// missingIPAMType returns true if the plugin configuration has an "ipam"
+// object that does not name the IPAM plugin to use.
+func missingIPAMType(net *PluginConfig) bool {
+	if !net.Network.IPAM.IsEmpty() {
+		return false
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(net.Bytes, &raw); err != nil {
+		return false
+	}
+	ipam, ok := raw["ipam"]
+	return ok && string(ipam) != "null"
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+var _ = Describe("Validating a network list in detail", func() {
+	var (
+		cniConfig *libcni.CNIConfig
+		ctx       context.Context
+	)
+
+	BeforeEach(func() {
+		exec := newVersionExec(map[string][]string{
+			"bridge":  {"0.4.0", "1.0.0", "1.1.0"},
+			"portmap": {"1.0.0", "1.1.0"},
+			"old":     {"0.4.0"},
+		})
+		cniConfig = libcni.NewCNIConfig([]string{exec.dir}, exec)
+		ctx = context.TODO()
+	})
+
+	validate := func(conf string) *libcni.ValidationResult {
+		list, err := libcni.NetworkConfFromBytes([]byte(conf))
+		Expect(err).NotTo(HaveOccurred())
+		return cniConfig.ValidateNetworkListDetailed(ctx, list, nil)
+	}
+
+	kinds := func(findings []*libcni.ValidationFinding) []libcni.FindingKind {
+		out := []libcni.FindingKind{}
+		for _, f := range findings {
+			out = append(out, f.Kind)
+		}
+		return out
+	}
+
+	It("reports no findings for a valid list", func() {
+		res := validate(`{"name": "test", "cniVersion": "1.0.0", "plugins": [
+			{"type": "bridge", "ipam": {"type": "host-local"}},
+			{"type": "portmap", "capabilities": {"portMappings": true}}]}`)
+		Expect(res.Findings).To(BeEmpty())
+		Expect(res.Err()).NotTo(HaveOccurred())
+		Expect(res.Capabilities).To(Equal([]string{"portMappings"}))
+	})
+
+	It("reports every problem with its plugin and severity", func() {
+		res := validate(`{"name": "bad name!", "cniVersion": "1.0.0", "plugins": [
+			{"type": "bridge", "ipam": {"subnet": "10.0.0.0/8"}},
+			{"type": "missing"},
+			{"type": "old"},
+			{"type": "bridge", "capabilities": {"portMappings": false, "made-up": true}}]}`)
+
+		Expect(kinds(res.Findings)).To(Equal([]libcni.FindingKind{
+			libcni.FindingInvalidNetworkName,
+			libcni.FindingMissingIPAMType,
+			libcni.FindingMissingPlugin,
+			libcni.FindingUnsupportedVersion,
+			libcni.FindingDuplicatePluginType,
+			libcni.FindingDisabledCapability,
+		}))
+		Expect(res.Findings[0].Plugin).To(Equal(-1))
+		Expect(res.Findings[0].Severity).To(Equal(libcni.SeverityWarning))
+		Expect(res.Findings[1].Severity).To(Equal(libcni.SeverityWarning))
+		Expect(res.Findings[3].Plugin).To(Equal(2))
+		Expect(res.Findings[3].PluginType).To(Equal("old"))
+		Expect(res.Findings[4].Severity).To(Equal(libcni.SeverityWarning))
+		Expect(res.Findings[5].Severity).To(Equal(libcni.SeverityInfo))
+
+		Expect(kinds(res.FindingsAtLeast(libcni.SeverityError))).To(Equal([]libcni.FindingKind{
+			libcni.FindingMissingPlugin,
+			libcni.FindingUnsupportedVersion,
+		}))
+		Expect(res.Capabilities).To(Equal([]string{"made-up"}))
+	})
+
+	It("reports capabilities the runtime does not pass", func() {
+		list := mustList(`{"name": "test", "cniVersion": "1.0.0", "plugins": [
+			{"type": "bridge", "capabilities": {"ips": true, "mac": false}},
+			{"type": "portmap", "capabilities": {"portMappings": true}}]}`)
+		res := cniConfig.ValidateNetworkListDetailed(ctx, list, &libcni.ValidateOptions{
+			RuntimeCapabilities: []string{"portMappings", "mac"},
+		})
+		Expect(kinds(res.Findings)).To(Equal([]libcni.FindingKind{
+			libcni.FindingUnconsumedCapability,
+			libcni.FindingDisabledCapability,
+		}))
+		Expect(res.Findings[0].Message).To(Equal(`plugin bridge declares capability "ips", which the runtime does not pass`))
+		Expect(res.Findings[0].Severity).To(Equal(libcni.SeverityWarning))
+		Expect(res.Capabilities).To(Equal([]string{"ips", "portMappings"}))
+	})
+
+	It("joins the errors so each can be extracted", func() {
+		res := validate(`{"name": "test", "cniVersion": "1.0.0", "plugins": [{"type": "old"}, {"type": "missing"}]}`)
+		err := res.Err()
+		Expect(err).To(MatchError("plugin old does not support config version \"1.0.0\"\n" +
+			"failed to find plugin \"missing\" in path [" + cniConfig.Path[0] + "]"))
+
+		var finding *libcni.ValidationFinding
+		Expect(errors.As(err, &finding)).To(BeTrue())
+		Expect(finding.Kind).To(Equal(libcni.FindingUnsupportedVersion))
+
+		_, err = cniConfig.ValidateNetworkList(ctx, mustList(`{"name": "test", "cniVersion": "1.0.0", "plugins": [{"type": "old"}]}`))
+		Expect(err).To(MatchError("plugin old does not support config version \"1.0.0\""))
+	})
+
+	It("does not fail ValidateNetworkList on warnings", func() {
+		caps, err := cniConfig.ValidateNetworkList(ctx, mustList(`{"name": "bad name!", "cniVersion": "1.0.0", "plugins": [
+			{"type": "bridge", "ipam": {}}, {"type": "bridge", "capabilities": {"custom": true}}]}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(caps).To(Equal([]string{"custom"}))
+	})
+
+	It("checks the schema when requested", func() {
+		list := mustList(`{"name": "test", "cniVersion": "1.0.0", "plugins": [
+			{"type": "bridge"}, {"type": "portmap", "args": "x", "runtimeConfig": []}]}`)
+		res := cniConfig.ValidateNetworkListDetailed(ctx, list, nil)
+		Expect(res.Findings).To(BeEmpty())
+
+		res = cniConfig.ValidateNetworkListDetailed(ctx, list, &libcni.ValidateOptions{CheckSchema: true})
+		Expect(kinds(res.Findings)).To(Equal([]libcni.FindingKind{
+			libcni.FindingSchemaViolation,
+			libcni.FindingSchemaViolation,
+		}))
+		Expect(res.Findings[0].Plugin).To(Equal(1))
+		Expect(res.Findings[0].Message).To(Equal("plugins[1].args: expected object, got string"))
+		Expect(res.Findings[1].Message).To(Equal("plugins[1].runtimeConfig: expected object, got array"))
+	})
+
+	It("negotiates the version when requested", func() {
+		list := mustList(`{"name": "test", "cniVersions": ["0.4.0", "1.0.0"], "plugins": [{"type": "bridge"}, {"type": "old"}]}`)
+		res := cniConfig.ValidateNetworkListDetailed(ctx, list, nil)
+		Expect(res.CNIVersion).To(Equal("1.0.0"))
+		Expect(kinds(res.Findings)).To(Equal([]libcni.FindingKind{libcni.FindingUnsupportedVersion}))
+
+		res = cniConfig.ValidateNetworkListDetailed(ctx, list, &libcni.ValidateOptions{NegotiateVersion: true})
+		Expect(res.CNIVersion).To(Equal("0.4.0"))
+		Expect(res.Findings).To(BeEmpty())
+		Expect(list.CNIVersion).To(Equal("1.0.0"))
+
+		list = mustList(`{"name": "test", "cniVersions": ["1.0.0", "1.1.0"], "plugins": [{"type": "portmap"}, {"type": "old"}]}`)
+		res = cniConfig.ValidateNetworkListDetailed(ctx, list, &libcni.ValidateOptions{NegotiateVersion: true})
+		Expect(kinds(res.Findings)).To(Equal([]libcni.FindingKind{
+			libcni.FindingNoCommonVersion,
+			libcni.FindingUnsupportedVersion,
+		}))
+	})
+})
+
+func mustList(conf string) *libcni.NetworkConfigList {
+	list, err := libcni.NetworkConfFromBytes([]byte(conf))
+	Expect(err).NotTo(HaveOccurred())
+	return list
+}
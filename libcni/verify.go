@@ -0,0 +1,193 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/containernetworking/cni/internal/integrity"
+)
+
+const (
+	// ChecksumExtension is appended to a configuration file's name to get
+	// the name of its checksum file, which holds the hex-encoded SHA-256
+	// digest of the file, as written by sha256sum
+	ChecksumExtension = ".sha256"
+	// SignatureExtension is appended to a configuration file's name to get
+	// the name of its signature file, which holds the ed25519 signature of
+	// the file, either raw or base64-encoded
+	SignatureExtension = ".sig"
+)
+
+// VerifyPolicy is what a ConfigLoader does with files that fail
+// verification
+type VerifyPolicy int
+
+const (
+	// VerifyReject fails loading files that fail verification
+	VerifyReject VerifyPolicy = iota
+	// VerifyWarn loads files that fail verification, after reporting them
+	// to VerifyOptions.OnWarning
+	VerifyWarn
+)
+
+// VerifyOptions configures the verification of configuration files read by
+// a ConfigLoader, including plugin configurations and included fragments.
+// Every file is checked against the checksum or signature file next to it:
+//
+//   - if PublicKeys is set, every file must have a signature file with a
+//     valid signature by one of the keys
+//   - otherwise, if RequireChecksum is set, every file must have a checksum
+//     file with its digest
+//   - otherwise, checksum and signature files are verified if they exist,
+//     and signatures are rejected since there is no key to verify them
+//
+// Files are verified as read, before YAML conversion or expansion.
+type VerifyOptions struct {
+	PublicKeys      []ed25519.PublicKey
+	RequireChecksum bool
+
+	Policy VerifyPolicy
+	// OnWarning is called for every file that fails verification when the
+	// policy is VerifyWarn
+	OnWarning func(file string, err error)
+}
+
+// VerificationError is returned for a configuration file that fails
+// verification
+type VerificationError struct {
+	File string
+	Err  error
+}
+
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("verification of %s failed: %v", e.File, e.Err)
+}
+
+func (e *VerificationError) Unwrap() error {
+	return e.Err
+}
+
+// ParsePublicKeys parses PEM-encoded ed25519 public keys, in "PUBLIC KEY"
+// blocks as written by `openssl pkey -pubout`
+func ParsePublicKeys(data []byte) ([]ed25519.PublicKey, error) {
+	var keys []ed25519.PublicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		edKey, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("unsupported public key type %T, expected ed25519", key)
+		}
+		keys = append(keys, edKey)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no public keys found")
+	}
+	return keys, nil
+}
+
+// verifyConfFS verifies every file it reads
+type verifyConfFS struct {
+	confFS
+	opts *VerifyOptions
+}
+
+func (c verifyConfFS) ReadFile(name string) ([]byte, error) {
+	data, err := c.confFS.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verify(name, data); err != nil {
+		verr := &VerificationError{File: name, Err: err}
+		if c.opts.Policy != VerifyWarn {
+			return nil, verr
+		}
+		if c.opts.OnWarning != nil {
+			c.opts.OnWarning(name, verr)
+		}
+	}
+	return data, nil
+}
+
+func (c verifyConfFS) verify(name string, data []byte) error {
+	sig, err := c.readSidecar(name + SignatureExtension)
+	if err != nil {
+		return err
+	}
+	sum, err := c.readSidecar(name + ChecksumExtension)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(c.opts.PublicKeys) > 0:
+		if sig == nil {
+			return fmt.Errorf("missing signature file %s", name+SignatureExtension)
+		}
+		return integrity.VerifySignature(data, sig, c.opts.PublicKeys)
+	case sig != nil:
+		return fmt.Errorf("signature file %s cannot be verified without public keys", name+SignatureExtension)
+	case sum != nil:
+		return verifyChecksum(data, sum)
+	case c.opts.RequireChecksum:
+		return fmt.Errorf("missing checksum file %s", name+ChecksumExtension)
+	}
+	return nil
+}
+
+// readSidecar returns the contents of a checksum or signature file, or nil
+// if it does not exist
+func (c verifyConfFS) readSidecar(name string) ([]byte, error) {
+	data, err := c.confFS.ReadFile(name)
+	switch {
+	case err == nil:
+		return data, nil
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, nil
+	}
+	return nil, err
+}
+
+func verifyChecksum(data, sum []byte) error {
+	fields := strings.Fields(string(sum))
+	if len(fields) == 0 {
+		return errors.New("empty checksum file")
+	}
+	want := strings.ToLower(fields[0])
+	if !integrity.IsDigest(want) {
+		return fmt.Errorf("invalid SHA-256 checksum %q", fields[0])
+	}
+	if got := integrity.Digest(data); got != want {
+		return fmt.Errorf("checksum mismatch: got %s, expected %s", got, want)
+	}
+	return nil
+}
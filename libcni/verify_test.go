@@ -0,0 +1,197 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+var _ = Describe("Verifying configuration files", func() {
+	const (
+		conflist = `{"cniVersion": "1.0.0", "name": "net", "plugins": [{"type": "bridge"}]}`
+		fragment = `{"type": "portmap"}`
+	)
+
+	var (
+		fsys    fstest.MapFS
+		pub     ed25519.PublicKey
+		priv    ed25519.PrivateKey
+		loader  *libcni.ConfigLoader
+		options *libcni.VerifyOptions
+	)
+
+	sign := func(name string) {
+		sig := ed25519.Sign(priv, fsys[name].Data)
+		fsys[name+libcni.SignatureExtension] = mapFile(base64.StdEncoding.EncodeToString(sig) + "\n")
+	}
+	checksum := func(name string) {
+		sum := sha256.Sum256(fsys[name].Data)
+		fsys[name+libcni.ChecksumExtension] = mapFile(hex.EncodeToString(sum[:]) + "  " + name + "\n")
+	}
+
+	BeforeEach(func() {
+		var err error
+		pub, priv, err = ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+
+		fsys = fstest.MapFS{
+			"10-net.conflist":     mapFile(conflist),
+			"net/10-portmap.conf": mapFile(fragment),
+		}
+		options = &libcni.VerifyOptions{}
+		loader = &libcni.ConfigLoader{FS: fsys, Verify: options}
+	})
+
+	Context("with public keys", func() {
+		BeforeEach(func() {
+			options.PublicKeys = []ed25519.PublicKey{pub}
+		})
+
+		It("loads signed files", func() {
+			sign("10-net.conflist")
+			sign("net/10-portmap.conf")
+			list, err := loader.LoadNetworkConf(".", "net")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.Plugins).To(HaveLen(2))
+		})
+
+		It("accepts raw signatures and any of the keys", func() {
+			otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			options.PublicKeys = []ed25519.PublicKey{otherPub, pub}
+
+			fsys["10-net.conflist.sig"] = &fstest.MapFile{Data: ed25519.Sign(priv, []byte(conflist))}
+			data, err := loader.ReadFile("10-net.conflist")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(conflist))
+		})
+
+		It("rejects unsigned, tampered and wrongly signed files", func() {
+			sign("10-net.conflist")
+			_, err := loader.LoadNetworkConf(".", "net")
+			Expect(err).To(MatchError("error reading net/10-portmap.conf: verification of net/10-portmap.conf failed: missing signature file net/10-portmap.conf.sig"))
+			var verr *libcni.VerificationError
+			Expect(errors.As(err, &verr)).To(BeTrue())
+			Expect(verr.File).To(Equal("net/10-portmap.conf"))
+
+			fsys["10-net.conflist"] = mapFile(`{"cniVersion": "1.0.0", "name": "net", "plugins": [{"type": "evil"}]}`)
+			_, err = loader.NetworkConfFromFile("10-net.conflist")
+			Expect(err).To(MatchError(ContainSubstring("signature does not match any public key")))
+
+			_, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			priv = otherPriv
+			sign("10-net.conflist")
+			_, err = loader.NetworkConfFromFile("10-net.conflist")
+			Expect(err).To(MatchError(ContainSubstring("signature does not match any public key")))
+
+			fsys["10-net.conflist.sig"] = mapFile("not base64!")
+			_, err = loader.NetworkConfFromFile("10-net.conflist")
+			Expect(err).To(MatchError(ContainSubstring("invalid signature encoding")))
+		})
+
+		It("only warns with the warn policy", func() {
+			var warned []string
+			options.Policy = libcni.VerifyWarn
+			options.OnWarning = func(file string, err error) {
+				Expect(err).To(BeAssignableToTypeOf(&libcni.VerificationError{}))
+				warned = append(warned, file)
+			}
+
+			sign("10-net.conflist")
+			list, err := loader.LoadNetworkConf(".", "net")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.Plugins).To(HaveLen(2))
+			Expect(warned).To(Equal([]string{"net/10-portmap.conf"}))
+		})
+
+		It("verifies files before expansion", func() {
+			fsys["20-exp.conflist"] = mapFile(`{"cniVersion": "1.0.0", "name": "${NAME}", "plugins": [{"type": "bridge"}]}`)
+			sign("20-exp.conflist")
+			loader.Expand = &libcni.ExpandOptions{Vars: map[string]string{"NAME": "expanded"}}
+			list, err := loader.NetworkConfFromFile("20-exp.conflist")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.Name).To(Equal("expanded"))
+		})
+	})
+
+	Context("with checksums", func() {
+		It("verifies checksum files that exist", func() {
+			checksum("10-net.conflist")
+			_, err := loader.LoadNetworkConf(".", "net")
+			Expect(err).NotTo(HaveOccurred())
+
+			fsys["10-net.conflist.sha256"] = mapFile("0000000000000000000000000000000000000000000000000000000000000000\n")
+			_, err = loader.LoadNetworkConf(".", "net")
+			Expect(err).To(MatchError(ContainSubstring("verification of 10-net.conflist failed: checksum mismatch")))
+
+			fsys["10-net.conflist.sha256"] = mapFile("xyz\n")
+			_, err = loader.LoadNetworkConf(".", "net")
+			Expect(err).To(MatchError(ContainSubstring(`invalid SHA-256 checksum "xyz"`)))
+		})
+
+		It("requires checksums if configured", func() {
+			options.RequireChecksum = true
+			checksum("10-net.conflist")
+			_, err := loader.LoadNetworkConf(".", "net")
+			Expect(err).To(MatchError(ContainSubstring("missing checksum file net/10-portmap.conf.sha256")))
+
+			checksum("net/10-portmap.conf")
+			_, err = loader.LoadNetworkConf(".", "net")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects signatures without public keys", func() {
+			sign("10-net.conflist")
+			_, err := loader.NetworkConfFromFile("10-net.conflist")
+			Expect(err).To(MatchError(ContainSubstring("signature file 10-net.conflist.sig cannot be verified without public keys")))
+		})
+	})
+
+	It("does not load checksum and signature files as configurations", func() {
+		sign("10-net.conflist")
+		checksum("10-net.conflist")
+		configs, err := libcni.LoadNetworkConfigsFS(fsys, ".")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configs.Files).To(HaveLen(1))
+	})
+
+	It("parses PEM public keys", func() {
+		der, err := x509.MarshalPKIXPublicKey(pub)
+		Expect(err).NotTo(HaveOccurred())
+		data := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "COMMENT", Bytes: []byte("x")})...)
+
+		keys, err := libcni.ParsePublicKeys(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(Equal([]ed25519.PublicKey{pub}))
+
+		_, err = libcni.ParsePublicKeys([]byte("nothing"))
+		Expect(err).To(MatchError("no public keys found"))
+	})
+})
@@ -0,0 +1,131 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/containernetworking/cni/internal/integrity"
+	"github.com/containernetworking/cni/pkg/invoke"
+	"github.com/containernetworking/cni/pkg/version"
+)
+
+// pluginIdentity identifies a specific plugin binary on disk. A plugin that
+// is upgraded in place, or replaced through a rename, gets a new identity.
+type pluginIdentity struct {
+	file integrity.FileID
+	// current is the spec version this library implemented when probing,
+	// which is passed to the plugin and may change its VERSION output
+	current string
+}
+
+type versionCacheEntry struct {
+	identity pluginIdentity
+	info     version.PluginInfo
+}
+
+// versionCacheKey is a plugin path and the Exec it was probed with, see
+// execIdentity
+type versionCacheKey struct {
+	path string
+	exec invoke.Exec
+}
+
+// versionCache holds the VERSION output of plugins. It is shared by all
+// CNIConfig instances in the process.
+type versionCache struct {
+	sync.Mutex
+	entries map[versionCacheKey]*versionCacheEntry
+}
+
+var pluginVersionCache = &versionCache{entries: map[versionCacheKey]*versionCacheEntry{}}
+
+func statPluginIdentity(pluginPath string) (pluginIdentity, bool) {
+	fi, err := os.Stat(pluginPath)
+	if err != nil || !fi.Mode().IsRegular() {
+		return pluginIdentity{}, false
+	}
+	return pluginIdentity{
+		file:    integrity.StatFileID(fi),
+		current: version.Current(),
+	}, true
+}
+
+// execIdentity returns the Exec to key cached VERSION output by, and false
+// if the output must not be cached. Every DefaultExec runs the binary on
+// disk, so they share their results. Other Execs may answer differently,
+// and only share results with themselves.
+func execIdentity(exec invoke.Exec) (invoke.Exec, bool) {
+	if _, ok := exec.(*invoke.DefaultExec); ok {
+		return nil, true
+	}
+	if !reflect.TypeOf(exec).Comparable() {
+		return nil, false
+	}
+	return exec, true
+}
+
+func (c *versionCache) get(key versionCacheKey, id pluginIdentity) version.PluginInfo {
+	c.Lock()
+	defer c.Unlock()
+	if e, ok := c.entries[key]; ok && e.identity == id {
+		return e.info
+	}
+	return nil
+}
+
+func (c *versionCache) put(key versionCacheKey, id pluginIdentity, info version.PluginInfo) {
+	c.Lock()
+	defer c.Unlock()
+	c.entries[key] = &versionCacheEntry{identity: id, info: info}
+}
+
+// ClearPluginVersionCache forgets the cached VERSION output of all plugins,
+// forcing them to be probed again.
+func ClearPluginVersionCache() {
+	pluginVersionCache.Lock()
+	defer pluginVersionCache.Unlock()
+	pluginVersionCache.entries = map[versionCacheKey]*versionCacheEntry{}
+}
+
+// getVersionInfo returns the version info of the plugin at pluginPath. The
+// plugin is only executed if it has not been probed before with an
+// equivalent Exec, or if its binary has changed since. Plugins that cannot
+// be stat'ed are never cached.
+func (c *CNIConfig) getVersionInfo(ctx context.Context, pluginPath string) (version.PluginInfo, error) {
+	exec := c.ensureExec()
+	id, ok := statPluginIdentity(pluginPath)
+	key := versionCacheKey{path: pluginPath}
+	if ok {
+		key.exec, ok = execIdentity(exec)
+	}
+	if ok {
+		if vi := pluginVersionCache.get(key, id); vi != nil {
+			return vi, nil
+		}
+	}
+
+	vi, err := invoke.GetVersionInfo(ctx, pluginPath, exec)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		pluginVersionCache.put(key, id, vi)
+	}
+	return vi, nil
+}
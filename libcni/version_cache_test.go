@@ -0,0 +1,113 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/containernetworking/cni/pkg/version"
+)
+
+var _ = Describe("Caching plugin version info", func() {
+	var (
+		exec *versionExec
+		ctx  context.Context
+	)
+
+	BeforeEach(func() {
+		exec = newVersionExec(map[string][]string{
+			"plugin": {"0.4.0", "1.0.0"},
+		})
+		ctx = context.TODO()
+	})
+
+	It("caches probe results across CNIConfig instances", func() {
+		netConfig, err := libcni.NetworkPluginConfFromBytes([]byte(`{"name": "test", "cniVersion": "1.0.0", "type": "plugin"}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := 0; i < 3; i++ {
+			// A runtime may create a CNIConfig for every network
+			cniConfig := libcni.NewCNIConfig([]string{exec.dir}, exec)
+			vi, err := cniConfig.GetVersionInfo(ctx, "plugin")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vi.SupportedVersions()).To(Equal([]string{"0.4.0", "1.0.0"}))
+
+			_, err = cniConfig.ValidateNetwork(ctx, netConfig)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(exec.calls["plugin"]).To(Equal(1))
+	})
+
+	It("does not share probe results between Execs", func() {
+		_, err := libcni.NewCNIConfig([]string{exec.dir}, exec).GetVersionInfo(ctx, "plugin")
+		Expect(err).NotTo(HaveOccurred())
+
+		// Another Exec may answer differently for the same binary
+		other := newVersionExec(map[string][]string{"plugin": {"1.1.0"}})
+		other.dir = exec.dir
+		vi, err := libcni.NewCNIConfig([]string{exec.dir}, other).GetVersionInfo(ctx, "plugin")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vi.SupportedVersions()).To(Equal([]string{"1.1.0"}))
+		Expect(other.calls["plugin"]).To(Equal(1))
+	})
+
+	It("probes the plugin again when its binary changes", func() {
+		cniConfig := libcni.NewCNIConfig([]string{exec.dir}, exec)
+		_, err := cniConfig.GetVersionInfo(ctx, "plugin")
+		Expect(err).NotTo(HaveOccurred())
+
+		By("replacing the binary through a rename")
+		exec.versions["plugin"] = []string{"1.0.0", "1.1.0"}
+		tmp := filepath.Join(exec.dir, ".plugin.new")
+		Expect(os.WriteFile(tmp, []byte("new"), 0o755)).To(Succeed())
+		Expect(os.Rename(tmp, filepath.Join(exec.dir, "plugin"))).To(Succeed())
+
+		vi, err := cniConfig.GetVersionInfo(ctx, "plugin")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vi.SupportedVersions()).To(Equal([]string{"1.0.0", "1.1.0"}))
+		Expect(exec.calls["plugin"]).To(Equal(2))
+
+		_, err = cniConfig.GetVersionInfo(ctx, "plugin")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exec.calls["plugin"]).To(Equal(2))
+	})
+
+	It("probes the plugin again when the implemented version changes", func() {
+		cniConfig := libcni.NewCNIConfig([]string{exec.dir}, exec)
+		_, err := cniConfig.GetVersionInfo(ctx, "plugin")
+		Expect(err).NotTo(HaveOccurred())
+
+		DeferCleanup(version.SetDraftEnabled(!version.DraftEnabled()))
+		_, err = cniConfig.GetVersionInfo(ctx, "plugin")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exec.calls["plugin"]).To(Equal(2))
+	})
+
+	It("does not cache plugins that cannot be found on disk", func() {
+		Expect(os.Remove(filepath.Join(exec.dir, "plugin"))).To(Succeed())
+		cniConfig := libcni.NewCNIConfig([]string{exec.dir}, exec)
+		for i := 0; i < 2; i++ {
+			_, err := cniConfig.GetVersionInfo(ctx, "plugin")
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(exec.calls["plugin"]).To(Equal(2))
+	})
+})
@@ -0,0 +1,350 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// WatchEventType is the kind of change reported by a ConfigWatcher
+type WatchEventType string
+
+const (
+	// NetworkAdded is sent when a network gets an effective configuration
+	NetworkAdded WatchEventType = "Added"
+	// NetworkChanged is sent when the effective configuration of a network
+	// changes, including plugins loaded from <dir>/<network-name>/*.conf
+	NetworkChanged WatchEventType = "Changed"
+	// NetworkRemoved is sent when a network no longer has a configuration
+	NetworkRemoved WatchEventType = "Removed"
+	// ConfigParseError is sent when a configuration file fails to load.
+	// It is sent again only if the file's error changes.
+	ConfigParseError WatchEventType = "ParseError"
+)
+
+// WatchEvent is a change to the network configurations in a directory
+type WatchEvent struct {
+	Type WatchEventType
+	// Name is the network name; it is empty for ConfigParseError
+	Name string
+	// File is the file providing the network, or the file that failed to
+	// load. For NetworkRemoved it is the file that provided the network.
+	File string
+
+	// OldConfig and OldBytes are the previous configuration, for
+	// NetworkChanged and NetworkRemoved
+	OldConfig *NetworkConfigList
+	OldBytes  []byte
+	// Config and Bytes are the new configuration, for NetworkAdded and
+	// NetworkChanged
+	Config *NetworkConfigList
+	Bytes  []byte
+
+	// Err is the load error, for ConfigParseError
+	Err error
+}
+
+// WatchOptions configures a ConfigWatcher. The zero value is usable.
+type WatchOptions struct {
+	// Debounce is how long the directory must be quiet after a change
+	// before it is re-read. Defaults to 100ms.
+	Debounce time.Duration
+	// PollInterval is how often the directory is re-read when file system
+	// notifications are not available or Poll is set. Defaults to 5s.
+	PollInterval time.Duration
+	// Poll disables file system notifications
+	Poll bool
+	// Loader, if set, loads the directory instead of LoadNetworkConfigs,
+	// e.g. to verify files or expand placeholders. Its FS must be nil,
+	// since the watcher watches the operating system's file system.
+	Loader *ConfigLoader
+}
+
+// ConfigWatcher watches a directory of network configurations, as loaded by
+// LoadNetworkConfigs or WatchOptions.Loader, and reports changes to the effective configuration of
+// each network. Since the directory is re-read as a whole once changes have
+// settled, configurations installed by writing a temporary file and renaming
+// it into place are never seen half-written.
+type ConfigWatcher struct {
+	dir    string
+	opts   WatchOptions
+	loader ConfigLoader
+	events chan WatchEvent
+
+	mu        sync.Mutex
+	snapshot  *NetworkConfigs
+	effective map[string]*NetworkConfigFile
+	errs      map[string]string
+
+	notifier notifier
+	// watching is true while the notifier watches the directory
+	watching  bool
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// notifier reports that something changed in the watched directories
+type notifier interface {
+	// Add starts watching a directory; it may be called repeatedly
+	Add(dir string) error
+	Changes() <-chan struct{}
+	Close() error
+}
+
+// errNotifyUnsupported is returned by newNotifier on platforms without
+// file system notifications
+var errNotifyUnsupported = errors.New("file system notifications are not supported")
+
+// NewConfigWatcher loads the configurations in dir and starts watching it.
+// Events describe changes after this initial load, which is available from
+// Snapshot. The directory does not need to exist yet. Events must be
+// received from Events until Close is called, since the watcher waits for
+// each event to be received.
+func NewConfigWatcher(dir string, opts *WatchOptions) (*ConfigWatcher, error) {
+	w := &ConfigWatcher{
+		dir:    dir,
+		events: make(chan WatchEvent, 64),
+		done:   make(chan struct{}),
+	}
+	if opts != nil {
+		w.opts = *opts
+	}
+	if w.opts.Loader != nil {
+		if w.opts.Loader.FS != nil {
+			return nil, errors.New("the loader of a ConfigWatcher can not have an FS")
+		}
+		w.loader = *w.opts.Loader
+	}
+	if w.opts.Debounce <= 0 {
+		w.opts.Debounce = 100 * time.Millisecond
+	}
+	if w.opts.PollInterval <= 0 {
+		w.opts.PollInterval = 5 * time.Second
+	}
+
+	if !w.opts.Poll {
+		n, err := newNotifier()
+		switch {
+		case err == nil:
+			w.notifier = n
+		case !errors.Is(err, errNotifyUnsupported):
+			return nil, err
+		}
+	}
+
+	w.effective = map[string]*NetworkConfigFile{}
+	w.errs = map[string]string{}
+	w.rescan(false)
+
+	w.wg.Add(1)
+	go w.run()
+	return w, nil
+}
+
+// Events returns the channel events are sent on. It is closed by Close.
+func (w *ConfigWatcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// Snapshot returns the configurations as of the last time the directory
+// was read. Use its Names and Get methods for the current valid set. It is
+// nil if the directory does not exist or contains no configurations.
+func (w *ConfigWatcher) Snapshot() *NetworkConfigs {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.snapshot
+}
+
+// Close stops watching and closes the events channel
+func (w *ConfigWatcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		if w.notifier != nil {
+			err = w.notifier.Close()
+		}
+		w.wg.Wait()
+		close(w.events)
+	})
+	return err
+}
+
+func (w *ConfigWatcher) run() {
+	defer w.wg.Done()
+
+	var changes <-chan struct{}
+	if w.notifier != nil {
+		changes = w.notifier.Changes()
+	}
+	// Poll while notifications are not available, including while the
+	// directory does not exist.
+	poll := time.NewTicker(w.opts.PollInterval)
+	defer poll.Stop()
+
+	debounce := time.NewTimer(w.opts.Debounce)
+	debounce.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case _, ok := <-changes:
+			if !ok {
+				changes = nil
+				continue
+			}
+			debounce.Reset(w.opts.Debounce)
+		case <-debounce.C:
+			if !w.rescan(true) {
+				return
+			}
+		case <-poll.C:
+			if w.watching {
+				continue
+			}
+			if !w.rescan(true) {
+				return
+			}
+		}
+	}
+}
+
+// rescan reloads the directory and, if send is true, sends the resulting
+// events. It returns false if the watcher was closed while sending.
+func (w *ConfigWatcher) rescan(send bool) bool {
+	w.watchDirs()
+
+	configs, err := w.loader.LoadNetworkConfigs(w.dir)
+	if err != nil {
+		// A missing or empty directory has no networks
+		configs = nil
+	}
+
+	effective := map[string]*NetworkConfigFile{}
+	errs := map[string]string{}
+	var events []WatchEvent
+	if configs != nil {
+		for _, f := range configs.Files {
+			if f.Err != nil {
+				errs[f.File] = f.Err.Error()
+				if w.errs[f.File] != errs[f.File] {
+					events = append(events, WatchEvent{Type: ConfigParseError, File: f.File, Err: f.Err})
+				}
+				continue
+			}
+			if !f.Effective {
+				continue
+			}
+			name := f.Config.Name
+			effective[name] = f
+			old, ok := w.effective[name]
+			switch {
+			case !ok:
+				events = append(events, WatchEvent{
+					Type:   NetworkAdded,
+					Name:   name,
+					File:   f.File,
+					Config: f.Config,
+					Bytes:  f.Config.Bytes,
+				})
+			case old.File != f.File || !sameNetworkConfig(old.Config, f.Config):
+				events = append(events, WatchEvent{
+					Type:      NetworkChanged,
+					Name:      name,
+					File:      f.File,
+					OldConfig: old.Config,
+					OldBytes:  old.Config.Bytes,
+					Config:    f.Config,
+					Bytes:     f.Config.Bytes,
+				})
+			}
+		}
+	}
+	removed := make([]string, 0, len(w.effective))
+	for name := range w.effective {
+		if _, ok := effective[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		old := w.effective[name]
+		events = append(events, WatchEvent{
+			Type:      NetworkRemoved,
+			Name:      name,
+			File:      old.File,
+			OldConfig: old.Config,
+			OldBytes:  old.Config.Bytes,
+		})
+	}
+
+	w.mu.Lock()
+	w.snapshot = configs
+	w.mu.Unlock()
+	w.effective = effective
+	w.errs = errs
+
+	if !send {
+		return true
+	}
+	for _, ev := range events {
+		select {
+		case w.events <- ev:
+		case <-w.done:
+			return false
+		}
+	}
+	return true
+}
+
+// watchDirs watches the configuration directory and its subdirectories,
+// which may hold plugin configurations
+func (w *ConfigWatcher) watchDirs() {
+	if w.notifier == nil {
+		return
+	}
+	w.watching = w.notifier.Add(w.dir) == nil
+	if !w.watching {
+		return
+	}
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			_ = w.notifier.Add(filepath.Join(w.dir, e.Name()))
+		}
+	}
+}
+
+func sameNetworkConfig(a, b *NetworkConfigList) bool {
+	if !bytes.Equal(a.Bytes, b.Bytes) || len(a.Plugins) != len(b.Plugins) {
+		return false
+	}
+	for i := range a.Plugins {
+		if !bytes.Equal(a.Plugins[i].Bytes, b.Plugins[i].Bytes) {
+			return false
+		}
+	}
+	return true
+}
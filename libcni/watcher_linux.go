@@ -0,0 +1,122 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package libcni
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+const inotifyMask = syscall.IN_CREATE | syscall.IN_MODIFY | syscall.IN_CLOSE_WRITE | syscall.IN_DELETE |
+	syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO | syscall.IN_ATTRIB |
+	syscall.IN_DELETE_SELF | syscall.IN_MOVE_SELF
+
+// inotifyNotifier watches directories with inotify. Individual events are
+// not decoded since any change causes the whole directory to be re-read.
+type inotifyNotifier struct {
+	file    *os.File
+	changes chan struct{}
+
+	mu      sync.Mutex
+	watches map[string]bool
+}
+
+func newNotifier() (notifier, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize inotify: %w", err)
+	}
+	n := &inotifyNotifier{
+		// A non-blocking descriptor lets the runtime poller unblock Read
+		// when the file is closed
+		file:    os.NewFile(uintptr(fd), "inotify"),
+		changes: make(chan struct{}, 1),
+		watches: map[string]bool{},
+	}
+	go n.read()
+	return n, nil
+}
+
+func (n *inotifyNotifier) Add(dir string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.watches[dir] {
+		return nil
+	}
+	// Fd would switch the descriptor to blocking mode, so use Control,
+	// which also fails once the file is closed
+	conn, err := n.file.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var addErr error
+	if err := conn.Control(func(fd uintptr) {
+		_, addErr = syscall.InotifyAddWatch(int(fd), dir, inotifyMask|syscall.IN_ONLYDIR)
+	}); err != nil {
+		return err
+	}
+	if addErr != nil {
+		return addErr
+	}
+	n.watches[dir] = true
+	return nil
+}
+
+func (n *inotifyNotifier) Changes() <-chan struct{} {
+	return n.changes
+}
+
+func (n *inotifyNotifier) Close() error {
+	return n.file.Close()
+}
+
+func (n *inotifyNotifier) read() {
+	defer close(n.changes)
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		count, err := n.file.Read(buf)
+		if err != nil {
+			return
+		}
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= count; {
+			ev := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			if ev.Mask&(syscall.IN_DELETE_SELF|syscall.IN_MOVE_SELF|syscall.IN_IGNORED) != 0 {
+				// The directory is gone or was replaced, so it has to
+				// be watched again once it exists.
+				n.forgetAll()
+			}
+			offset += syscall.SizeofInotifyEvent + int(ev.Len)
+		}
+		select {
+		case n.changes <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// forgetAll drops the record of watched directories so that they are added
+// again by the next Add. Watches that still exist are returned unchanged by
+// inotify_add_watch.
+func (n *inotifyNotifier) forgetAll() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.watches = map[string]bool{}
+}
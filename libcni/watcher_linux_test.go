@@ -0,0 +1,61 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package libcni_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+// inotifyFDs counts the inotify descriptors open in this process
+func inotifyFDs() int {
+	entries, err := os.ReadDir("/proc/self/fd")
+	Expect(err).NotTo(HaveOccurred())
+	count := 0
+	for _, e := range entries {
+		if target, err := os.Readlink(filepath.Join("/proc/self/fd", e.Name())); err == nil && target == "anon_inode:inotify" {
+			count++
+		}
+	}
+	return count
+}
+
+var _ = Describe("Watching network configurations with inotify", func() {
+	It("releases the inotify descriptor on Close after changes", func() {
+		configDir := GinkgoT().TempDir()
+		before := inotifyFDs()
+
+		watcher, err := libcni.NewConfigWatcher(configDir, &libcni.WatchOptions{Debounce: 20 * time.Millisecond})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inotifyFDs()).To(Equal(before + 1))
+
+		// The change wakes the reader, which then waits for the next one
+		Expect(os.WriteFile(filepath.Join(configDir, "10-a.conflist"),
+			[]byte(`{"cniVersion": "1.0.0", "name": "a", "plugins": [{"type": "bridge"}]}`), 0o600)).To(Succeed())
+		Eventually(watcher.Events(), "5s").Should(Receive())
+
+		Expect(watcher.Close()).To(Succeed())
+		Eventually(inotifyFDs, "5s").Should(Equal(before))
+	})
+})
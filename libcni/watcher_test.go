@@ -0,0 +1,217 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+var _ = Describe("Watching network configurations", func() {
+	var (
+		configDir string
+		watcher   *libcni.ConfigWatcher
+	)
+
+	write := func(name, content string) string {
+		path := filepath.Join(configDir, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o700)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return path
+	}
+
+	nextEvent := func() libcni.WatchEvent {
+		var ev libcni.WatchEvent
+		EventuallyWithOffset(1, watcher.Events(), "5s").Should(Receive(&ev))
+		return ev
+	}
+
+	BeforeEach(func() {
+		configDir = GinkgoT().TempDir()
+	})
+
+	AfterEach(func() {
+		if watcher != nil {
+			Expect(watcher.Close()).To(Succeed())
+			watcher = nil
+		}
+	})
+
+	for _, poll := range []bool{false, true} {
+		poll := poll
+		opts := func() *libcni.WatchOptions {
+			return &libcni.WatchOptions{
+				Debounce:     20 * time.Millisecond,
+				PollInterval: 50 * time.Millisecond,
+				Poll:         poll,
+			}
+		}
+
+		Context("with Poll set to "+map[bool]string{false: "false", true: "true"}[poll], func() {
+			It("reports networks being added, changed and removed", func() {
+				file := write("10-a.conflist", `{"cniVersion": "1.0.0", "name": "a", "plugins": [{"type": "bridge"}]}`)
+
+				var err error
+				watcher, err = libcni.NewConfigWatcher(configDir, opts())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.Snapshot().Names()).To(Equal([]string{"a"}))
+
+				other := write("20-b.conflist", `{"cniVersion": "1.0.0", "name": "b", "plugins": [{"type": "ptp"}]}`)
+				ev := nextEvent()
+				Expect(ev.Type).To(Equal(libcni.NetworkAdded))
+				Expect(ev.Name).To(Equal("b"))
+				Expect(ev.File).To(Equal(other))
+				Expect(ev.Config.Plugins[0].Network.Type).To(Equal("ptp"))
+				Expect(watcher.Snapshot().Names()).To(Equal([]string{"a", "b"}))
+
+				write("10-a.conflist", `{"cniVersion": "1.0.0", "name": "a", "plugins": [{"type": "macvlan"}]}`)
+				ev = nextEvent()
+				Expect(ev.Type).To(Equal(libcni.NetworkChanged))
+				Expect(ev.Name).To(Equal("a"))
+				Expect(ev.File).To(Equal(file))
+				Expect(ev.OldConfig.Plugins[0].Network.Type).To(Equal("bridge"))
+				Expect(ev.Config.Plugins[0].Network.Type).To(Equal("macvlan"))
+				Expect(string(ev.Bytes)).To(ContainSubstring("macvlan"))
+
+				Expect(os.Remove(other)).To(Succeed())
+				ev = nextEvent()
+				Expect(ev.Type).To(Equal(libcni.NetworkRemoved))
+				Expect(ev.Name).To(Equal("b"))
+				Expect(ev.File).To(Equal(other))
+				Expect(ev.OldConfig.Plugins[0].Network.Type).To(Equal("ptp"))
+				Expect(watcher.Snapshot().Names()).To(Equal([]string{"a"}))
+				Consistently(watcher.Events(), "200ms").ShouldNot(Receive())
+			})
+
+			It("reports files that fail to load once", func() {
+				var err error
+				watcher, err = libcni.NewConfigWatcher(configDir, opts())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.Snapshot()).To(BeNil())
+
+				bad := write("10-bad.conflist", `{"name": `)
+				ev := nextEvent()
+				Expect(ev.Type).To(Equal(libcni.ConfigParseError))
+				Expect(ev.File).To(Equal(bad))
+				Expect(ev.Err).To(HaveOccurred())
+				Consistently(watcher.Events(), "200ms").ShouldNot(Receive())
+
+				write("10-bad.conflist", `{"cniVersion": "1.0.0", "name": "fixed", "plugins": [{"type": "bridge"}]}`)
+				ev = nextEvent()
+				Expect(ev.Type).To(Equal(libcni.NetworkAdded))
+				Expect(ev.Name).To(Equal("fixed"))
+			})
+
+			It("sees configurations installed by renaming a temporary file", func() {
+				var err error
+				watcher, err = libcni.NewConfigWatcher(configDir, opts())
+				Expect(err).NotTo(HaveOccurred())
+
+				tmp := write(".10-a.conflist.tmp", `{"cniVersion": "1.0.0", "name": "a", "plugins": [{"type": "bridge"}]}`)
+				file := filepath.Join(configDir, "10-a.conflist")
+				Expect(os.Rename(tmp, file)).To(Succeed())
+
+				ev := nextEvent()
+				Expect(ev.Type).To(Equal(libcni.NetworkAdded))
+				Expect(ev.Name).To(Equal("a"))
+				Expect(ev.File).To(Equal(file))
+				Consistently(watcher.Events(), "200ms").ShouldNot(Receive())
+			})
+
+			It("reports changes to plugins in the network's directory", func() {
+				write("10-a.conflist", `{"cniVersion": "1.0.0", "name": "a"}`)
+				write("a/10-bridge.conf", `{"type": "bridge"}`)
+
+				var err error
+				watcher, err = libcni.NewConfigWatcher(configDir, opts())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.Snapshot().Get("a").Plugins).To(HaveLen(1))
+
+				write("a/20-portmap.conf", `{"type": "portmap"}`)
+				ev := nextEvent()
+				Expect(ev.Type).To(Equal(libcni.NetworkChanged))
+				Expect(ev.Name).To(Equal("a"))
+				Expect(ev.OldConfig.Plugins).To(HaveLen(1))
+				Expect(ev.Config.Plugins).To(HaveLen(2))
+				Expect(ev.Config.Plugins[1].Network.Type).To(Equal("portmap"))
+			})
+		})
+	}
+
+	It("loads the directory with the given loader", func() {
+		write("10-a.conflist", `{"cniVersion": "1.0.0", "name": "a", "plugins": [{"type": "${TYPE}"}]}`)
+
+		var err error
+		watcher, err = libcni.NewConfigWatcher(configDir, &libcni.WatchOptions{
+			Debounce: 20 * time.Millisecond,
+			Loader: &libcni.ConfigLoader{Expand: &libcni.ExpandOptions{
+				Vars:   map[string]string{"TYPE": "bridge"},
+				Lookup: func(string) (string, bool) { return "", false },
+			}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(watcher.Snapshot().Get("a").Plugins[0].Network.Type).To(Equal("bridge"))
+
+		write("20-b.conflist", `{"cniVersion": "1.0.0", "name": "b", "plugins": [{"type": "${UNSET}"}]}`)
+		ev := nextEvent()
+		Expect(ev.Type).To(Equal(libcni.ConfigParseError))
+		Expect(ev.Err).To(MatchError(libcni.UndefinedVariableError{Name: "UNSET"}))
+	})
+
+	It("rejects loaders reading another file system", func() {
+		_, err := libcni.NewConfigWatcher(configDir, &libcni.WatchOptions{
+			Loader: &libcni.ConfigLoader{FS: os.DirFS(configDir)},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("starts watching a directory once it is created", func() {
+		configDir = filepath.Join(configDir, "net.d")
+
+		var err error
+		watcher, err = libcni.NewConfigWatcher(configDir, &libcni.WatchOptions{
+			Debounce:     20 * time.Millisecond,
+			PollInterval: 50 * time.Millisecond,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(watcher.Snapshot()).To(BeNil())
+
+		write("10-a.conflist", `{"cniVersion": "1.0.0", "name": "a", "plugins": [{"type": "bridge"}]}`)
+		ev := nextEvent()
+		Expect(ev.Type).To(Equal(libcni.NetworkAdded))
+		Expect(ev.Name).To(Equal("a"))
+
+		write("20-b.conflist", `{"cniVersion": "1.0.0", "name": "b", "plugins": [{"type": "bridge"}]}`)
+		ev = nextEvent()
+		Expect(ev.Type).To(Equal(libcni.NetworkAdded))
+		Expect(ev.Name).To(Equal("b"))
+	})
+
+	It("closes the events channel on Close", func() {
+		var err error
+		watcher, err = libcni.NewConfigWatcher(configDir, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(watcher.Close()).To(Succeed())
+		Eventually(watcher.Events()).Should(BeClosed())
+		Expect(watcher.Close()).To(Succeed())
+		watcher = nil
+	})
+})
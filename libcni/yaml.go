@@ -0,0 +1,214 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLConfListExtensions are the file name suffixes of configuration lists
+// written in YAML. A ConfigLoader with YAML set loads them alongside
+// .conflist files, sorted with them by file name; the package-level loaders
+// ignore them.
+var YAMLConfListExtensions = []string{".conflist.yaml", ".conflist.yml"}
+
+func isYAMLConfList(name string) bool {
+	for _, ext := range YAMLConfListExtensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// isConfListFile returns true for configuration list files: .conflist
+// files, and YAML configuration lists if cfs loads them
+func isConfListFile(cfs confFS, name string) bool {
+	return strings.HasSuffix(name, ".conflist") || (cfs.YAML() && isYAMLConfList(name))
+}
+
+// confListFiles returns the configuration list files in dir, in lexical
+// order
+func confListFiles(cfs confFS, dir string) ([]string, error) {
+	extensions := []string{".conflist"}
+	if cfs.YAML() {
+		extensions = append(extensions, ".yaml", ".yml")
+	}
+	files, err := confFiles(cfs, dir, extensions)
+	if err != nil {
+		return nil, err
+	}
+	lists := files[:0]
+	for _, f := range files {
+		if isConfListFile(cfs, f) {
+			lists = append(lists, f)
+		}
+	}
+	sort.Strings(lists)
+	return lists, nil
+}
+
+// NetworkConfFromYAML parses a configuration list written in YAML. The
+// result is the same as NetworkConfFromBytes for the equivalent JSON, with
+// Bytes holding the configuration as compact JSON with sorted keys.
+func NetworkConfFromYAML(data []byte) (*NetworkConfigList, error) {
+	jsonBytes, err := yamlToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return NetworkConfFromBytes(jsonBytes)
+}
+
+// yamlToJSON converts a single YAML document to JSON. Anchors, aliases and
+// merge keys are resolved; values that cannot be represented in JSON, such
+// as non-scalar keys or infinite numbers, are rejected.
+func yamlToJSON(data []byte) ([]byte, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	var doc yaml.Node
+	if err := dec.Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("error parsing configuration list: empty YAML document")
+		}
+		return nil, fmt.Errorf("error parsing configuration list: %w", err)
+	}
+	var extra yaml.Node
+	if err := dec.Decode(&extra); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error parsing configuration list: expected a single YAML document")
+	}
+
+	value, err := yamlNodeValue(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing configuration list: %w", err)
+	}
+	return json.Marshal(value)
+}
+
+func yamlNodeValue(n *yaml.Node) (interface{}, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return yamlNodeValue(n.Content[0])
+	case yaml.AliasNode:
+		return yamlNodeValue(n.Alias)
+	case yaml.SequenceNode:
+		list := make([]interface{}, 0, len(n.Content))
+		for _, c := range n.Content {
+			v, err := yamlNodeValue(c)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case yaml.MappingNode:
+		return yamlMapping(n)
+	case yaml.ScalarNode:
+		return yamlScalar(n)
+	}
+	return nil, fmt.Errorf("line %d: unsupported YAML node", n.Line)
+}
+
+func yamlMapping(n *yaml.Node) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	// Keys set by merge keys ("<<") are overridden by explicit keys
+	merged := map[string]bool{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if key.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("line %d: mapping keys must be scalars", key.Line)
+		}
+
+		if key.ShortTag() == "!!merge" {
+			sources := []*yaml.Node{value}
+			if value.Kind == yaml.SequenceNode {
+				sources = value.Content
+			}
+			for _, src := range sources {
+				v, err := yamlNodeValue(src)
+				if err != nil {
+					return nil, err
+				}
+				mm, ok := v.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("line %d: merge key value must be a mapping", src.Line)
+				}
+				for k, v := range mm {
+					if _, ok := m[k]; !ok {
+						m[k] = v
+						merged[k] = true
+					}
+				}
+			}
+			continue
+		}
+
+		if _, ok := m[key.Value]; ok && !merged[key.Value] {
+			return nil, fmt.Errorf("line %d: duplicate key %q", key.Line, key.Value)
+		}
+		v, err := yamlNodeValue(value)
+		if err != nil {
+			return nil, err
+		}
+		m[key.Value] = v
+		delete(merged, key.Value)
+	}
+	return m, nil
+}
+
+func yamlScalar(n *yaml.Node) (interface{}, error) {
+	switch n.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err != nil {
+			return nil, err
+		}
+		return b, nil
+	case "!!int":
+		var i int64
+		if err := n.Decode(&i); err != nil {
+			var u uint64
+			if err := n.Decode(&u); err != nil {
+				return nil, fmt.Errorf("line %d: invalid integer %q", n.Line, n.Value)
+			}
+			return json.Number(strconv.FormatUint(u, 10)), nil
+		}
+		return json.Number(strconv.FormatInt(i, 10)), nil
+	case "!!float":
+		var f float64
+		if err := n.Decode(&f); err != nil {
+			return nil, err
+		}
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, fmt.Errorf("line %d: %q cannot be represented in JSON", n.Line, n.Value)
+		}
+		return f, nil
+	}
+	// Strings, and timestamps or binary data in their textual form
+	return n.Value, nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libcni_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+const yamlConfList = `
+cniVersion: 1.0.0
+name: mynet
+disableCheck: true
+plugins:
+  - type: bridge
+    bridge: cni0
+    isGateway: true
+    mtu: 1450
+    ipam:
+      type: host-local
+      ranges:
+        - - subnet: 10.1.2.0/24
+      routes:
+        - dst: 0.0.0.0/0
+  - type: portmap
+    capabilities:
+      portMappings: true
+`
+
+const jsonConfList = `{
+	"cniVersion": "1.0.0",
+	"name": "mynet",
+	"disableCheck": true,
+	"plugins": [
+		{
+			"type": "bridge",
+			"bridge": "cni0",
+			"isGateway": true,
+			"mtu": 1450,
+			"ipam": {
+				"type": "host-local",
+				"ranges": [[{"subnet": "10.1.2.0/24"}]],
+				"routes": [{"dst": "0.0.0.0/0"}]
+			}
+		},
+		{"type": "portmap", "capabilities": {"portMappings": true}}
+	]
+}`
+
+var _ = Describe("YAML configuration lists", func() {
+	It("parses to the same list as the equivalent JSON", func() {
+		fromYAML, err := libcni.NetworkConfFromYAML([]byte(yamlConfList))
+		Expect(err).NotTo(HaveOccurred())
+		fromJSON, err := libcni.NetworkConfFromBytes([]byte(jsonConfList))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fromYAML.Bytes).To(MatchJSON(fromJSON.Bytes))
+		Expect(string(fromYAML.Bytes)).To(HavePrefix(`{"cniVersion":"1.0.0","disableCheck":true,"name":"mynet","plugins":[`))
+		fromYAML.Bytes, fromJSON.Bytes = nil, nil
+		Expect(fromYAML).To(Equal(fromJSON))
+	})
+
+	It("resolves anchors and merge keys", func() {
+		list, err := libcni.NetworkConfFromYAML([]byte(`
+cniVersion: 1.0.0
+name: merged
+defaults: &defaults
+  mtu: 1450
+  hairpinMode: true
+plugins:
+  - <<: *defaults
+    type: bridge
+    mtu: 9000
+  - <<: *defaults
+    type: ptp
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(list.Plugins[0].Bytes)).To(Equal(`{"hairpinMode":true,"mtu":9000,"type":"bridge"}`))
+		Expect(string(list.Plugins[1].Bytes)).To(Equal(`{"hairpinMode":true,"mtu":1450,"type":"ptp"}`))
+	})
+
+	It("keeps quoted and unusual scalars as strings", func() {
+		list, err := libcni.NetworkConfFromYAML([]byte(`
+cniVersion: "1.0.0"
+name: "0123"
+plugins:
+  - type: bridge
+    big: 18446744073709551615
+    hex: 0x10
+    ratio: 0.5
+    created: 2026-01-02
+    "on": yes
+    nothing: ~
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Name).To(Equal("0123"))
+		Expect(list.Plugins[0].Bytes).To(MatchJSON(`{
+			"type": "bridge", "big": 18446744073709551615, "hex": 16, "ratio": 0.5,
+			"created": "2026-01-02", "on": "yes", "nothing": null
+		}`))
+	})
+
+	It("rejects YAML that cannot be represented as JSON", func() {
+		for in, msg := range map[string]string{
+			"name: a\n---\nname: b\n":         "expected a single YAML document",
+			"name: a\nname: b\n":              `line 2: duplicate key "name"`,
+			"name: a\nmtu: .inf\n":            `line 2: ".inf" cannot be represented in JSON`,
+			"name: a\n? [x]\n: y\n":           "line 2: mapping keys must be scalars",
+			"name: a\nx:\n  <<: [1]\n":        "line 3: merge key value must be a mapping",
+			"":                                "empty YAML document",
+			"name: [unterminated\n":           "error parsing configuration list: yaml:",
+			"- not\n- a mapping\n":            "error parsing configuration list",
+			"cniVersion: 1.0.0\nname: a\n":    "",
+			"cniVersion: 1.0.0\nname: 12\n":   "invalid name type",
+			"cniVersion: 1.0.0\nname: true\n": "invalid name type",
+		} {
+			_, err := libcni.NetworkConfFromYAML([]byte(in))
+			if msg == "" {
+				Expect(err).NotTo(HaveOccurred(), in)
+				continue
+			}
+			Expect(err).To(MatchError(ContainSubstring(msg)), in)
+		}
+	})
+
+	Describe("loading from a directory", func() {
+		var (
+			dir    string
+			loader *libcni.ConfigLoader
+		)
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			for name, content := range map[string]string{
+				"10-first.conflist":      `{"cniVersion": "1.0.0", "name": "first", "plugins": [{"type": "bridge"}]}`,
+				"20-mynet.conflist.yaml": yamlConfList,
+				"30-yml.conflist.yml":    "cniVersion: 1.0.0\nname: yml\nplugins:\n  - type: ptp\n",
+				"40-ignored.yaml":        "cniVersion: 1.0.0\nname: ignored\nplugins:\n  - type: ptp\n",
+				"mynet/50-tuning.conf":   `{"type": "tuning"}`,
+			} {
+				path := filepath.Join(dir, name)
+				Expect(os.MkdirAll(filepath.Dir(path), 0o700)).To(Succeed())
+				Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+			}
+			loader = &libcni.ConfigLoader{YAML: true}
+		})
+
+		It("loads YAML configuration lists with their plugin directory", func() {
+			list, err := loader.LoadNetworkConf(dir, "mynet")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.Plugins).To(HaveLen(3))
+			Expect(list.Plugins[2].Network.Type).To(Equal("tuning"))
+			Expect(list.Bytes).To(MatchJSON(jsonConfList))
+
+			_, err = loader.LoadNetworkConf(dir, "ignored")
+			Expect(err).To(MatchError(libcni.NotFoundError{Dir: dir, Name: "ignored"}))
+
+			list, err = loader.LoadNetworkConfFile(filepath.Join(dir, "30-yml.conflist.yml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.Name).To(Equal("yml"))
+		})
+
+		It("sorts YAML and JSON lists together", func() {
+			configs, err := loader.LoadNetworkConfigs(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configs.Names()).To(Equal([]string{"first", "mynet", "yml"}))
+		})
+
+		It("ignores YAML unless enabled", func() {
+			_, err := libcni.LoadNetworkConf(dir, "mynet")
+			Expect(err).To(MatchError(libcni.NotFoundError{Dir: dir, Name: "mynet"}))
+
+			configs, err := libcni.LoadNetworkConfigs(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configs.Names()).To(Equal([]string{"first"}))
+			Expect(configs.Files).To(HaveLen(1))
+		})
+	})
+
+	It("expands placeholders after converting to JSON", func() {
+		fsys := fstest.MapFS{
+			"10-net.conflist.yaml": mapFile("cniVersion: 1.0.0\nname: net\nplugins:\n  - type: bridge\n    bridge: ${BRIDGE}\n"),
+		}
+		loader := &libcni.ConfigLoader{
+			FS:     fsys,
+			YAML:   true,
+			Expand: &libcni.ExpandOptions{Vars: map[string]string{"BRIDGE": `br"0`}},
+		}
+		data, err := loader.ReadFile("10-net.conflist.yaml")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"cniVersion":"1.0.0","name":"net","plugins":[{"bridge":"br\"0","type":"bridge"}]}`))
+	})
+
+	It("expands placeholders in YAML to strings", func() {
+		fsys := fstest.MapFS{
+			"10-net.conflist.yaml": mapFile("cniVersion: 1.0.0\nname: net\nplugins:\n  - type: bridge\n    mtu: ${MTU}\n"),
+		}
+		loader := &libcni.ConfigLoader{
+			FS:     fsys,
+			YAML:   true,
+			Expand: &libcni.ExpandOptions{Vars: map[string]string{"MTU": "1450"}},
+		}
+		list, err := loader.NetworkConfFromFile("10-net.conflist.yaml")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(list.Plugins[0].Bytes)).To(Equal(`{"mtu":"1450","type":"bridge"}`))
+	})
+})
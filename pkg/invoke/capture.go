@@ -0,0 +1,99 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invoke
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// maxStderrLine is the longest stderr line passed to RawExec.StderrLine;
+// longer lines are split
+const maxStderrLine = 64 * 1024
+
+// cappedBuffer keeps the first max bytes written to it and counts the rest.
+// A max of zero or less keeps everything. Writes never fail, so a plugin is
+// not disturbed by its output being discarded.
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated int64
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if b.max > 0 {
+		if room := b.max - b.buf.Len(); room < len(p) {
+			if room < 0 {
+				room = 0
+			}
+			b.truncated += int64(len(p) - room)
+			p = p[:room]
+		}
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+func (b *cappedBuffer) Len() int {
+	return b.buf.Len()
+}
+
+// Bytes returns the kept bytes
+func (b *cappedBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+// marked returns the kept bytes followed by a marker if any were discarded
+func (b *cappedBuffer) marked() []byte {
+	if b.truncated == 0 {
+		return b.buf.Bytes()
+	}
+	return append(b.buf.Bytes(), fmt.Sprintf("... [%d bytes truncated]", b.truncated)...)
+}
+
+// lineWriter calls fn with every line written to it, without the line
+// ending. flush passes on a final unterminated line.
+type lineWriter struct {
+	fn      func(line string)
+	partial []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			w.partial = append(w.partial, p...)
+			for len(w.partial) >= maxStderrLine {
+				w.fn(string(w.partial[:maxStderrLine]))
+				w.partial = w.partial[maxStderrLine:]
+			}
+			break
+		}
+		line := append(w.partial, p[:i]...)
+		w.partial = w.partial[:0]
+		w.fn(string(bytes.TrimSuffix(line, []byte("\r"))))
+		p = p[i+1:]
+	}
+	return n, nil
+}
+
+func (w *lineWriter) flush() {
+	if len(w.partial) > 0 {
+		w.fn(string(w.partial))
+		w.partial = nil
+	}
+}
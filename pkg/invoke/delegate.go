@@ -81,6 +81,13 @@ func DelegateGC(ctx context.Context, delegatePlugin string, netconf []byte, exec
 	return delegateNoResult(ctx, delegatePlugin, netconf, exec, "GC")
 }
 
+// DelegateValidate calls the given delegate plugin with the experimental CNI
+// VALIDATE action and JSON configuration. Plugins that do not implement
+// VALIDATE return an error for which ValidateUnsupported returns true.
+func DelegateValidate(ctx context.Context, delegatePlugin string, netconf []byte, exec Exec) error {
+	return delegateNoResult(ctx, delegatePlugin, netconf, exec, "VALIDATE")
+}
+
 // return CNIArgs used by delegation
 func delegateArgs(action string) *DelegateArgs {
 	return &DelegateArgs{
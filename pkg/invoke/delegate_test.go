@@ -17,6 +17,8 @@ package invoke_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
@@ -25,6 +27,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/containernetworking/cni/pkg/invoke"
+	"github.com/containernetworking/cni/pkg/types"
 	current "github.com/containernetworking/cni/pkg/types/100"
 	"github.com/containernetworking/cni/pkg/version"
 	"github.com/containernetworking/cni/plugins/test/noop/debug"
@@ -267,4 +270,51 @@ var _ = Describe("Delegate", func() {
 			})
 		})
 	})
+
+	Describe("DelegateValidate", func() {
+		It("finds and execs the named plugin", func() {
+			err := invoke.DelegateValidate(ctx, pluginName, netConf, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			pluginInvocation, err := debug.ReadDebug(debugFileName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pluginInvocation.Command).To(Equal("VALIDATE"))
+		})
+
+		It("returns the error reported by the plugin", func() {
+			debugBehavior.ReportError = "bad range"
+			debugBehavior.ReportErrorCode = 7
+			Expect(debugBehavior.WriteDebug(debugFileName)).To(Succeed())
+
+			err := invoke.DelegateValidate(ctx, pluginName, netConf, nil)
+			Expect(err).To(MatchError("bad range"))
+			Expect(invoke.ValidateUnsupported(err)).To(BeFalse())
+		})
+
+		It("recognizes plugins that do not implement it", func() {
+			debugBehavior.ReportError = "unknown CNI_COMMAND: VALIDATE"
+			debugBehavior.ReportErrorCode = types.ErrInvalidEnvironmentVariables
+			Expect(debugBehavior.WriteDebug(debugFileName)).To(Succeed())
+
+			err := invoke.DelegateValidate(ctx, pluginName, netConf, nil)
+			Expect(invoke.ValidateUnsupported(err)).To(BeTrue())
+			Expect(invoke.ValidateUnsupported(fmt.Errorf("wrapped: %w", err))).To(BeTrue())
+
+			// Other errors with the same code or message do not count
+			Expect(invoke.ValidateUnsupported(types.NewError(types.ErrInvalidEnvironmentVariables, "CNI_NETNS is missing", ""))).To(BeFalse())
+			Expect(invoke.ValidateUnsupported(types.NewError(types.ErrInvalidEnvironmentVariables, "VALIDATE needs CNI_NETNS", ""))).To(BeFalse())
+			Expect(invoke.ValidateUnsupported(errors.New("unknown CNI_COMMAND: VALIDATE"))).To(BeFalse())
+		})
+
+		Context("when the plugin cannot be found", func() {
+			BeforeEach(func() {
+				pluginName = "non-existent-plugin"
+			})
+
+			It("returns a useful error", func() {
+				err := invoke.DelegateValidate(ctx, pluginName, netConf, nil)
+				Expect(err).To(MatchError(HavePrefix("failed to find plugin")))
+			})
+		})
+	})
 })
@@ -17,6 +17,7 @@ package invoke
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 
@@ -144,6 +145,17 @@ func ExecPluginWithoutResult(ctx context.Context, pluginPath string, netconf []b
 	return err
 }
 
+// ValidateUnsupported returns true if the error returned by a VALIDATE call
+// reports that the plugin does not implement the experimental command. Such
+// plugins reject it like any unknown command: plugins built with skel
+// return an ErrInvalidEnvironmentVariables error with the exact message
+// "unknown CNI_COMMAND: VALIDATE", which is what is checked.
+func ValidateUnsupported(err error) bool {
+	var e *types.Error
+	return errors.As(err, &e) && e.Code == types.ErrInvalidEnvironmentVariables &&
+		e.Msg == "unknown CNI_COMMAND: VALIDATE"
+}
+
 // GetVersionInfo returns the version information available about the plugin.
 // For recent-enough plugins, it uses the information returned by the VERSION
 // command.  For older plugins which do not recognize that command, it reports
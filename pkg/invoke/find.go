@@ -18,21 +18,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
 // FindInPath returns the full path of the plugin by searching in the provided path
 func FindInPath(plugin string, paths []string) (string, error) {
-	if plugin == "" {
-		return "", fmt.Errorf("no plugin name provided")
-	}
-
-	if strings.ContainsRune(plugin, os.PathSeparator) {
-		return "", fmt.Errorf("invalid plugin name: %s", plugin)
-	}
-
-	if len(paths) == 0 {
-		return "", fmt.Errorf("no paths provided")
+	if err := checkFindArgs(plugin, paths); err != nil {
+		return "", err
 	}
 
 	for _, path := range paths {
@@ -46,3 +39,105 @@ func FindInPath(plugin string, paths []string) (string, error) {
 
 	return "", fmt.Errorf("failed to find plugin %q in path %s", plugin, paths)
 }
+
+// PluginCandidate is a file in the search path that could provide a plugin
+type PluginCandidate struct {
+	// Path is the full path of the file
+	Path string
+	// DirIndex is the position of the file's directory in the search path
+	DirIndex int
+	Mode     os.FileMode
+	// Executable is false if the file lacks execute permission, in which
+	// case invoking it fails even though FindInPath selects it
+	Executable bool
+	// Selected is true for the candidate FindInPath returns; every other
+	// candidate is shadowed by it
+	Selected bool
+}
+
+// FindAllInPath returns every file in the provided path that could provide
+// the plugin, in the order FindInPath considers them. The first candidate is
+// the one FindInPath returns. It returns an error if there are none.
+func FindAllInPath(plugin string, paths []string) ([]*PluginCandidate, error) {
+	if err := checkFindArgs(plugin, paths); err != nil {
+		return nil, err
+	}
+
+	var candidates []*PluginCandidate
+	for i, path := range paths {
+		for _, fe := range ExecutableFileExtensions {
+			fullpath := filepath.Join(path, plugin) + fe
+			fi, err := os.Stat(fullpath)
+			if err != nil || !fi.Mode().IsRegular() {
+				continue
+			}
+			candidates = append(candidates, &PluginCandidate{
+				Path:       fullpath,
+				DirIndex:   i,
+				Mode:       fi.Mode(),
+				Executable: isExecutable(fi.Mode()),
+				Selected:   len(candidates) == 0,
+			})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("failed to find plugin %q in path %s", plugin, paths)
+	}
+	return candidates, nil
+}
+
+// ListPluginsInPath returns the sorted names of the plugins in the provided
+// path, that is of the executable regular files it contains. File name
+// extensions in ExecutableFileExtensions are removed. Directories that do
+// not exist are skipped.
+func ListPluginsInPath(paths []string) ([]string, error) {
+	seen := map[string]bool{}
+	for _, path := range paths {
+		entries, err := os.ReadDir(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			// Stat follows symlinks, as FindInPath does
+			fi, err := os.Stat(filepath.Join(path, e.Name()))
+			if err != nil || !fi.Mode().IsRegular() || !isExecutable(fi.Mode()) {
+				continue
+			}
+			seen[pluginName(e.Name())] = true
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// pluginName returns the plugin name FindInPath resolves to file
+func pluginName(file string) string {
+	for _, fe := range ExecutableFileExtensions {
+		if fe != "" && strings.EqualFold(filepath.Ext(file), fe) {
+			return strings.TrimSuffix(file, filepath.Ext(file))
+		}
+	}
+	return file
+}
+
+func checkFindArgs(plugin string, paths []string) error {
+	if plugin == "" {
+		return fmt.Errorf("no plugin name provided")
+	}
+
+	if strings.ContainsRune(plugin, os.PathSeparator) {
+		return fmt.Errorf("invalid plugin name: %s", plugin)
+	}
+
+	if len(paths) == 0 {
+		return fmt.Errorf("no paths provided")
+	}
+	return nil
+}
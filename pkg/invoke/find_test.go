@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -109,3 +110,82 @@ var _ = Describe("FindInPath", func() {
 		})
 	})
 })
+
+var _ = Describe("FindAllInPath", func() {
+	var first, second, empty string
+
+	writePlugin := func(dir, name string, mode os.FileMode) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte("#!/bin/sh\n"), mode)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		first = GinkgoT().TempDir()
+		second = GinkgoT().TempDir()
+		empty = GinkgoT().TempDir()
+	})
+
+	It("returns every candidate in search order and marks the first as selected", func() {
+		shadowed := writePlugin(second, "bridge", 0o755)
+		selected := writePlugin(first, "bridge", 0o755)
+
+		candidates, err := invoke.FindAllInPath("bridge", []string{empty, first, second})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(candidates).To(HaveLen(2))
+		Expect(*candidates[0]).To(Equal(invoke.PluginCandidate{
+			Path: selected, DirIndex: 1, Mode: 0o755, Executable: true, Selected: true,
+		}))
+		Expect(candidates[1].Path).To(Equal(shadowed))
+		Expect(candidates[1].DirIndex).To(Equal(2))
+		Expect(candidates[1].Selected).To(BeFalse())
+
+		path, err := invoke.FindInPath("bridge", []string{empty, first, second})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal(candidates[0].Path))
+	})
+
+	It("reports candidates without execute permission", func() {
+		if runtime.GOOS == "windows" {
+			Skip("Windows has no execute permission bits")
+		}
+		writePlugin(first, "bridge", 0o644)
+		writePlugin(second, "bridge", 0o755)
+
+		candidates, err := invoke.FindAllInPath("bridge", []string{first, second})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(candidates[0].Executable).To(BeFalse())
+		Expect(candidates[0].Selected).To(BeTrue())
+		Expect(candidates[1].Executable).To(BeTrue())
+	})
+
+	It("returns an error if there are no candidates", func() {
+		Expect(os.Mkdir(filepath.Join(first, "bridge"), 0o755)).To(Succeed())
+		_, err := invoke.FindAllInPath("bridge", []string{first})
+		Expect(err).To(MatchError(fmt.Sprintf("failed to find plugin %q in path %s", "bridge", []string{first})))
+
+		_, err = invoke.FindAllInPath("../bridge", []string{first})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("ListPluginsInPath", func() {
+	It("lists the executable files in every directory once", func() {
+		if runtime.GOOS == "windows" {
+			Skip("Windows has no execute permission bits")
+		}
+		first := GinkgoT().TempDir()
+		second := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(first, "ptp"), nil, 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(first, "bridge"), nil, 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(second, "bridge"), nil, 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(second, "README"), nil, 0o644)).To(Succeed())
+		Expect(os.Symlink(filepath.Join(first, "ptp"), filepath.Join(second, "loopback"))).To(Succeed())
+		Expect(os.Symlink(filepath.Join(first, "missing"), filepath.Join(second, "dangling"))).To(Succeed())
+		Expect(os.Mkdir(filepath.Join(second, "dir"), 0o755)).To(Succeed())
+
+		names, err := invoke.ListPluginsInPath([]string{first, filepath.Join(first, "missing"), second})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"bridge", "loopback", "ptp"}))
+	})
+})
@@ -0,0 +1,147 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inprocess runs CNI plugins in the calling process, through the
+// same protocol handling as plugin binaries built with skel.
+package inprocess
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/containernetworking/cni/internal/protocol"
+	"github.com/containernetworking/cni/pkg/invoke"
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/containernetworking/cni/pkg/types"
+	"github.com/containernetworking/cni/pkg/version"
+)
+
+// Exec is an invoke.Exec that runs plugins registered with it in the
+// calling process instead of executing binaries. Each call goes through the
+// same environment parsing, version checks and dispatch as a plugin binary
+// built with skel.PluginMainFuncs, and the plugin's output and errors are
+// returned exactly as if it had been executed. Plugin callbacks must write
+// their results with CmdArgs.PrintResult or to CmdArgs.Stdout, not to
+// os.Stdout.
+//
+// The zero value is usable and finds only registered plugins.
+type Exec struct {
+	// Fallback, if set, finds and executes plugins that are not registered
+	Fallback invoke.Exec
+	// Stderr receives what plugins write to their stderr. Defaults to
+	// os.Stderr.
+	Stderr io.Writer
+
+	version.PluginDecoder
+
+	mu      sync.RWMutex
+	plugins map[string]*inProcessPlugin
+}
+
+type inProcessPlugin struct {
+	funcs       skel.CNIFuncs
+	versionInfo version.PluginInfo
+}
+
+// Exec implements the invoke.Exec interface
+var _ invoke.Exec = &Exec{}
+
+// Register adds a plugin for the given type. It returns an error if the type
+// is not a valid plugin name or is already registered.
+func (e *Exec) Register(pluginType string, funcs skel.CNIFuncs, versionInfo version.PluginInfo) error {
+	if pluginType == "" || strings.ContainsAny(pluginType, `/\`) {
+		return fmt.Errorf("invalid plugin name: %q", pluginType)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.plugins[pluginType]; ok {
+		return fmt.Errorf("plugin %q is already registered", pluginType)
+	}
+	if e.plugins == nil {
+		e.plugins = map[string]*inProcessPlugin{}
+	}
+	e.plugins[pluginType] = &inProcessPlugin{funcs: funcs, versionInfo: versionInfo}
+	return nil
+}
+
+func (e *Exec) lookup(pluginType string) *inProcessPlugin {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.plugins[pluginType]
+}
+
+// FindInPath returns the plugin name itself for registered plugins, which
+// ExecPlugin then runs in-process. Since names never contain a path
+// separator, they cannot be confused with paths returned by Fallback.
+func (e *Exec) FindInPath(plugin string, paths []string) (string, error) {
+	if e.lookup(plugin) != nil {
+		return plugin, nil
+	}
+	if e.Fallback != nil {
+		return e.Fallback.FindInPath(plugin, paths)
+	}
+	return "", fmt.Errorf("failed to find plugin %q in path %s", plugin, paths)
+}
+
+// ExecPlugin runs the registered plugin named pluginPath, or passes the call
+// to Fallback for any other path.
+func (e *Exec) ExecPlugin(ctx context.Context, pluginPath string, stdinData []byte, environ []string) ([]byte, error) {
+	p := e.lookup(pluginPath)
+	if p == nil {
+		if e.Fallback != nil {
+			return e.Fallback.ExecPlugin(ctx, pluginPath, stdinData, environ)
+		}
+		return nil, fmt.Errorf("plugin %q is not registered", pluginPath)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	stderr := e.Stderr
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+	stdout := &bytes.Buffer{}
+	if err := runInProcess(p, skel.PluginIO{
+		// A log descriptor in environ is not one RawExec opened for this
+		// plugin, so log records go to Stderr instead
+		Getenv: protocol.GetenvWithoutLogFD(environ),
+		Stdin:  bytes.NewReader(stdinData),
+		Stdout: stdout,
+		Stderr: stderr,
+	}); err != nil {
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// runInProcess dispatches to the plugin, turning a panic into an error as a
+// crashing plugin binary would be
+func runInProcess(p *inProcessPlugin, pio skel.PluginIO) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &types.Error{Code: types.ErrInternal, Msg: fmt.Sprintf("netplugin failed: panic: %v", r)}
+		}
+	}()
+	if e := skel.PluginMainFuncsWithIO(p.funcs, p.versionInfo, "", pio); e != nil {
+		return e
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inprocess_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/pkg/invoke"
+	"github.com/containernetworking/cni/pkg/invoke/inprocess"
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/containernetworking/cni/pkg/types"
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/cni/pkg/version"
+)
+
+var _ = Describe("Exec", func() {
+	var (
+		exec     *inprocess.Exec
+		received []*skel.CmdArgs
+		netconf  []byte
+		args     *invoke.Args
+		ctx      context.Context
+	)
+
+	BeforeEach(func() {
+		received = nil
+		exec = &inprocess.Exec{}
+		Expect(exec.Register("test-plugin", skel.CNIFuncs{
+			Add: func(args *skel.CmdArgs) error {
+				received = append(received, args)
+				_, ipnet, _ := net.ParseCIDR("10.1.2.3/24")
+				ipnet.IP = net.IPv4(10, 1, 2, 3)
+				return args.PrintResult(&current.Result{
+					CNIVersion: "1.0.0",
+					IPs:        []*current.IPConfig{{Address: *ipnet}},
+				}, "1.0.0")
+			},
+			Del: func(args *skel.CmdArgs) error {
+				received = append(received, args)
+				return types.NewError(types.ErrTryAgainLater, "busy", "try again")
+			},
+			Check: func(*skel.CmdArgs) error {
+				panic("boom")
+			},
+		}, version.PluginSupports("0.4.0", "1.0.0"))).To(Succeed())
+
+		netconf = []byte(`{"cniVersion": "1.0.0", "name": "net", "type": "test-plugin"}`)
+		args = &invoke.Args{
+			Command:     "ADD",
+			ContainerID: "some-container-id",
+			NetNS:       "/some/netns",
+			IfName:      "eth0",
+			Path:        "/some/bin",
+		}
+		ctx = context.TODO()
+	})
+
+	It("finds registered plugins", func() {
+		path, err := exec.FindInPath("test-plugin", []string{"/some/bin"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal("test-plugin"))
+
+		_, err = exec.FindInPath("other", []string{"/some/bin"})
+		Expect(err).To(MatchError(`failed to find plugin "other" in path [/some/bin]`))
+	})
+
+	It("runs ADD and returns the result", func() {
+		result, err := invoke.ExecPluginWithResult(ctx, "test-plugin", netconf, args, exec)
+		Expect(err).NotTo(HaveOccurred())
+		res, err := current.NewResultFromResult(result)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.IPs).To(HaveLen(1))
+		Expect(res.IPs[0].Address.String()).To(Equal("10.1.2.3/24"))
+
+		Expect(received).To(HaveLen(1))
+		Expect(received[0].ContainerID).To(Equal("some-container-id"))
+		Expect(received[0].Netns).To(Equal("/some/netns"))
+		Expect(received[0].IfName).To(Equal("eth0"))
+		Expect(received[0].Path).To(Equal("/some/bin"))
+		Expect(received[0].StdinData).To(MatchJSON(netconf))
+	})
+
+	It("sends log records to Stderr rather than a descriptor named by the caller", func() {
+		stderr := &bytes.Buffer{}
+		exec.Stderr = stderr
+		Expect(exec.Register("logging-plugin", skel.CNIFuncs{
+			Add: func(args *skel.CmdArgs) error {
+				args.Logger(nil).Info("adding", "ifname", args.IfName)
+				return args.PrintResult(&current.Result{CNIVersion: "1.0.0"}, "1.0.0")
+			},
+		}, version.PluginSupports("1.0.0"))).To(Succeed())
+
+		environ := append(args.AsEnv(), "CNI_LOG_FD=9")
+		_, err := exec.ExecPlugin(ctx, "logging-plugin", netconf, environ)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stderr.String()).To(ContainSubstring(`"level":"INFO","msg":"adding","ifname":"eth0"}`))
+	})
+
+	It("returns plugin errors as a plugin binary would", func() {
+		args.Command = "DEL"
+		err := invoke.ExecPluginWithoutResult(ctx, "test-plugin", netconf, args, exec)
+		Expect(err).To(Equal(&types.Error{Code: types.ErrTryAgainLater, Msg: "busy", Details: "try again"}))
+	})
+
+	It("applies the protocol checks of skel", func() {
+		args.IfName = ""
+		_, err := invoke.ExecPluginWithResult(ctx, "test-plugin", netconf, args, exec)
+		Expect(err).To(MatchError("required env variables [CNI_IFNAME] missing"))
+
+		args.IfName = "eth0"
+		_, err = invoke.ExecPluginWithResult(ctx, "test-plugin", []byte(`{"cniVersion": "0.3.1", "name": "net"}`), args, exec)
+		Expect(err).To(MatchError(ContainSubstring("incompatible CNI versions")))
+		Expect(received).To(BeEmpty())
+	})
+
+	It("answers VERSION", func() {
+		vi, err := invoke.GetVersionInfo(ctx, "test-plugin", exec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vi.SupportedVersions()).To(Equal([]string{"0.4.0", "1.0.0"}))
+	})
+
+	It("turns panics into errors", func() {
+		args.Command = "CHECK"
+		err := invoke.ExecPluginWithoutResult(ctx, "test-plugin", netconf, args, exec)
+		Expect(err).To(MatchError("netplugin failed: panic: boom"))
+	})
+
+	It("does not run plugins for a canceled context", func() {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := invoke.ExecPluginWithResult(canceled, "test-plugin", netconf, args, exec)
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(received).To(BeEmpty())
+	})
+
+	It("rejects invalid and duplicate registrations", func() {
+		Expect(exec.Register("test-plugin", skel.CNIFuncs{}, version.All)).To(MatchError(`plugin "test-plugin" is already registered`))
+		Expect(exec.Register("../plugin", skel.CNIFuncs{}, version.All)).To(MatchError(`invalid plugin name: "../plugin"`))
+		Expect(exec.Register("", skel.CNIFuncs{}, version.All)).To(HaveOccurred())
+	})
+
+	Context("with a fallback", func() {
+		var fallback *fallbackExec
+
+		BeforeEach(func() {
+			fallback = &fallbackExec{}
+			exec.Fallback = fallback
+		})
+
+		It("passes unregistered plugins to the fallback", func() {
+			path, err := exec.FindInPath("other", []string{"/some/bin"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal(filepath.Join("/some/bin", "other")))
+
+			_, err = exec.ExecPlugin(ctx, path, netconf, nil)
+			Expect(err).To(MatchError("fallback executed " + path))
+
+			_, err = invoke.ExecPluginWithResult(ctx, "test-plugin", netconf, args, exec)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
+
+type fallbackExec struct {
+	version.PluginDecoder
+}
+
+func (f *fallbackExec) ExecPlugin(_ context.Context, pluginPath string, _ []byte, _ []string) ([]byte, error) {
+	return nil, errors.New("fallback executed " + pluginPath)
+}
+
+func (f *fallbackExec) FindInPath(plugin string, paths []string) (string, error) {
+	if len(paths) == 0 {
+		return "", fmt.Errorf("no paths provided")
+	}
+	return filepath.Join(paths[0], plugin), nil
+}
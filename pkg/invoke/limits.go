@@ -0,0 +1,69 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invoke
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// ProcessLimits restricts the resources of plugin processes started by
+// RawExec. Zero fields impose no limit. Setting a field that is not
+// supported on the current platform makes every execution fail rather than
+// run the plugin unrestricted.
+//
+// Go cannot set resource limits for a child process alone, so they are
+// applied right after the plugin starts; it runs unrestricted for that
+// short moment.
+type ProcessLimits struct {
+	// AddressSpace is the maximum size of the plugin's virtual memory in
+	// bytes (RLIMIT_AS). Linux only.
+	AddressSpace uint64
+	// CPUTime is the CPU time after which the plugin is killed
+	// (RLIMIT_CPU), rounded up to whole seconds. Linux only.
+	CPUTime time.Duration
+	// OpenFiles is the maximum number of file descriptors the plugin may
+	// open (RLIMIT_NOFILE). Linux only.
+	OpenFiles uint64
+
+	// ProcessGroup runs the plugin in a process group of its own, which is
+	// killed as a whole when the context ends, so processes the plugin
+	// started do not outlive it. Not supported on Windows.
+	ProcessGroup bool
+
+	// Cgroup is a cgroup v2 directory, for example
+	// /sys/fs/cgroup/cni-plugins. Each plugin runs in a new child cgroup of
+	// it, which is removed when the plugin exits, killing any processes
+	// left in it. Linux only.
+	Cgroup string
+	// CgroupSettings are written to the interface files of each child
+	// cgroup before the plugin starts, for example
+	// {"memory.max": "64M", "pids.max": "32"}
+	CgroupSettings map[string]string
+}
+
+func (l *ProcessLimits) hasRlimits() bool {
+	return l.AddressSpace != 0 || l.CPUTime != 0 || l.OpenFiles != 0
+}
+
+// cpuSeconds returns CPUTime rounded up to whole seconds
+func (l *ProcessLimits) cpuSeconds() uint64 {
+	return uint64((l.CPUTime + time.Second - 1) / time.Second)
+}
+
+func errLimitUnsupported(what string) error {
+	return fmt.Errorf("%s limits for plugins are not supported on %s", what, runtime.GOOS)
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package invoke
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// limitedProcess applies ProcessLimits to one plugin process
+type limitedProcess struct {
+	limits *ProcessLimits
+	cgroup *os.File
+}
+
+// prepare configures c before it is started, creating its cgroup
+func (l *ProcessLimits) prepare(c *exec.Cmd) (*limitedProcess, error) {
+	p := &limitedProcess{limits: l}
+	if c.SysProcAttr == nil {
+		c.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	if l.ProcessGroup {
+		setProcessGroup(c)
+	}
+	if l.Cgroup != "" {
+		if err := p.createCgroup(); err != nil {
+			return nil, err
+		}
+		c.SysProcAttr.UseCgroupFD = true
+		c.SysProcAttr.CgroupFD = int(p.cgroup.Fd())
+	} else if len(l.CgroupSettings) > 0 {
+		return nil, fmt.Errorf("cgroup settings require a cgroup")
+	}
+	return p, nil
+}
+
+// started applies the resource limits to the running plugin
+func (p *limitedProcess) started(pid int) error {
+	l := p.limits
+	if l.AddressSpace != 0 {
+		if err := prlimit(pid, syscall.RLIMIT_AS, l.AddressSpace, l.AddressSpace); err != nil {
+			return fmt.Errorf("failed to limit address space: %w", err)
+		}
+	}
+	if l.CPUTime != 0 {
+		// The soft limit sends SIGXCPU, the hard limit a second later
+		// SIGKILL
+		secs := l.cpuSeconds()
+		if err := prlimit(pid, syscall.RLIMIT_CPU, secs, secs+1); err != nil {
+			return fmt.Errorf("failed to limit CPU time: %w", err)
+		}
+	}
+	if l.OpenFiles != 0 {
+		if err := prlimit(pid, syscall.RLIMIT_NOFILE, l.OpenFiles, l.OpenFiles); err != nil {
+			return fmt.Errorf("failed to limit open files: %w", err)
+		}
+	}
+	return nil
+}
+
+// cleanup kills whatever is left in the plugin's cgroup and removes it
+func (p *limitedProcess) cleanup() {
+	if p.cgroup == nil {
+		return
+	}
+	dir := p.cgroup.Name()
+	p.cgroup.Close()
+
+	// cgroup.kill needs Linux 5.14; removal fails on older kernels if
+	// processes are left, and the cgroup is then left behind
+	_ = os.WriteFile(filepath.Join(dir, "cgroup.kill"), []byte("1"), 0)
+	for i := 0; i < 50; i++ {
+		if err := os.Remove(dir); err == nil || os.IsNotExist(err) {
+			return
+		}
+		if !cgroupPopulated(dir) {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	_ = os.Remove(dir)
+}
+
+func (p *limitedProcess) createCgroup() error {
+	dir, err := os.MkdirTemp(p.limits.Cgroup, "cni-plugin-")
+	if err != nil {
+		return fmt.Errorf("failed to create plugin cgroup: %w", err)
+	}
+	for name, value := range p.limits.CgroupSettings {
+		if name == "" || strings.ContainsRune(name, os.PathSeparator) {
+			_ = os.Remove(dir)
+			return fmt.Errorf("invalid cgroup interface file %q", name)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(value), 0); err != nil {
+			_ = os.Remove(dir)
+			return fmt.Errorf("failed to set %s of plugin cgroup: %w", name, err)
+		}
+	}
+	if p.cgroup, err = os.Open(dir); err != nil {
+		_ = os.Remove(dir)
+		return fmt.Errorf("failed to open plugin cgroup: %w", err)
+	}
+	return nil
+}
+
+// cgroupPopulated returns true if processes are left in the cgroup
+func cgroupPopulated(dir string) bool {
+	events, err := os.ReadFile(filepath.Join(dir, "cgroup.events"))
+	if err != nil {
+		return false
+	}
+	return bytes.Contains(events, []byte("populated 1"))
+}
+
+func prlimit(pid, resource int, soft, hard uint64) error {
+	rlim := syscall.Rlimit{Cur: soft, Max: hard}
+	_, _, errno := syscall.RawSyscall6(syscall.SYS_PRLIMIT64, uintptr(pid), uintptr(resource),
+		uintptr(unsafe.Pointer(&rlim)), 0, 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
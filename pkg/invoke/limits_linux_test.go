@@ -0,0 +1,127 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package invoke_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/pkg/invoke"
+)
+
+var _ = Describe("RawExec with process limits", func() {
+	var (
+		dir    string
+		execer *invoke.RawExec
+		ctx    context.Context
+	)
+
+	// writeScript writes a shell script plugin. The scripts sleep briefly
+	// first, since resource limits are applied right after the start.
+	writeScript := func(name, script string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte("#!/bin/sh\nsleep 0.2\n"+script), 0o755)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		execer = &invoke.RawExec{Limits: &invoke.ProcessLimits{}}
+		ctx = context.TODO()
+	})
+
+	It("applies resource limits", func() {
+		execer.Limits.AddressSpace = 1 << 30
+		execer.Limits.CPUTime = 1500 * time.Millisecond
+		execer.Limits.OpenFiles = 64
+		plugin := writeScript("limits", `echo "$(ulimit -v) $(ulimit -t) $(ulimit -n)"`)
+
+		out, err := execer.ExecPlugin(ctx, plugin, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.TrimSpace(string(out))).To(Equal("1048576 2 64"))
+	})
+
+	It("kills the plugin's process group when the context ends", func() {
+		execer.Limits.ProcessGroup = true
+		pidFile := filepath.Join(dir, "child.pid")
+		plugin := writeScript("spawner", `sleep 60 >/dev/null 2>&1 &
+echo $! > `+pidFile+`
+sleep 60
+`)
+
+		timeout, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		_, err := execer.ExecPlugin(timeout, plugin, nil, nil)
+		Expect(err).To(HaveOccurred())
+
+		data, err := os.ReadFile(pidFile)
+		Expect(err).NotTo(HaveOccurred())
+		pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		Expect(err).NotTo(HaveOccurred())
+		// The orphaned child is either gone or a zombie waiting for init
+		// to reap it
+		Eventually(func() bool {
+			stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+			if err != nil {
+				return true
+			}
+			fields := strings.Fields(string(stat[bytes.LastIndexByte(stat, ')')+1:]))
+			return len(fields) > 0 && fields[0] == "Z"
+		}).Should(BeTrue())
+	})
+
+	It("runs the plugin in a cgroup of its own", func() {
+		if _, err := os.Stat("/sys/fs/cgroup/cgroup.controllers"); err != nil {
+			Skip("cgroup v2 is not available")
+		}
+		parent, err := os.MkdirTemp("/sys/fs/cgroup", "cni-test-")
+		if err != nil {
+			Skip("cannot create cgroups: " + err.Error())
+		}
+		DeferCleanup(os.Remove, parent)
+
+		execer.Limits.Cgroup = parent
+		execer.Limits.CgroupSettings = map[string]string{"pids.max": "32"}
+		plugin := writeScript("cgroup", `cat /proc/self/cgroup`)
+
+		out, err := execer.ExecPlugin(ctx, plugin, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("/" + filepath.Base(parent) + "/cni-plugin-"))
+
+		entries, err := os.ReadDir(parent)
+		Expect(err).NotTo(HaveOccurred())
+		for _, e := range entries {
+			Expect(e.Name()).NotTo(HavePrefix("cni-plugin-"))
+		}
+	})
+
+	It("rejects cgroup settings without a cgroup", func() {
+		execer.Limits.CgroupSettings = map[string]string{"pids.max": "32"}
+		_, err := execer.ExecPlugin(ctx, writeScript("noop", "true"), nil, nil)
+		Expect(err).To(MatchError("cgroup settings require a cgroup"))
+	})
+})
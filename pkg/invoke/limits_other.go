@@ -0,0 +1,50 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd netbsd openbsd solaris
+
+package invoke
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// limitedProcess applies ProcessLimits to one plugin process
+type limitedProcess struct{}
+
+// prepare configures c before it is started. Only process groups are
+// supported.
+func (l *ProcessLimits) prepare(c *exec.Cmd) (*limitedProcess, error) {
+	switch {
+	case l.hasRlimits():
+		return nil, errLimitUnsupported("resource")
+	case l.Cgroup != "" || len(l.CgroupSettings) > 0:
+		return nil, errLimitUnsupported("cgroup")
+	}
+	if c.SysProcAttr == nil {
+		c.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	if l.ProcessGroup {
+		setProcessGroup(c)
+	}
+	return &limitedProcess{}, nil
+}
+
+func (p *limitedProcess) started(int) error {
+	return nil
+}
+
+func (p *limitedProcess) cleanup() {}
@@ -0,0 +1,39 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invoke
+
+import "os/exec"
+
+// limitedProcess applies ProcessLimits to one plugin process
+type limitedProcess struct{}
+
+// prepare fails if any limit is set, since none are supported
+func (l *ProcessLimits) prepare(*exec.Cmd) (*limitedProcess, error) {
+	switch {
+	case l.hasRlimits():
+		return nil, errLimitUnsupported("resource")
+	case l.ProcessGroup:
+		return nil, errLimitUnsupported("process group")
+	case l.Cgroup != "" || len(l.CgroupSettings) > 0:
+		return nil, errLimitUnsupported("cgroup")
+	}
+	return &limitedProcess{}, nil
+}
+
+func (p *limitedProcess) started(int) error {
+	return nil
+}
+
+func (p *limitedProcess) cleanup() {}
@@ -17,5 +17,12 @@
 
 package invoke
 
+import "os"
+
 // Valid file extensions for plugin executables.
 var ExecutableFileExtensions = []string{""}
+
+// isExecutable returns true if any execute bit is set
+func isExecutable(mode os.FileMode) bool {
+	return mode&0o111 != 0
+}
@@ -14,5 +14,12 @@
 
 package invoke
 
+import "os"
+
 // Valid file extensions for plugin executables.
 var ExecutableFileExtensions = []string{".exe", ""}
+
+// isExecutable returns true since Windows has no execute permission bits
+func isExecutable(os.FileMode) bool {
+	return true
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invoke
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/containernetworking/cni/internal/protocol"
+)
+
+const (
+	// EnvLogFD names the environment variable holding the file descriptor
+	// a plugin writes its log records to. RawExec sets it when it forwards
+	// plugin logs, see RawExec.LogHandler.
+	EnvLogFD = protocol.EnvLogFD
+	// EnvLogLevel names the environment variable holding the lowest level
+	// of log records the runtime is interested in, such as "DEBUG" or
+	// "WARN"
+	EnvLogLevel = protocol.EnvLogLevel
+)
+
+// logDrainTimeout bounds how long log records are read after the plugin
+// exits, in case a process it started keeps the log pipe open
+const logDrainTimeout = time.Second
+
+// logForwarder passes the JSON log records a plugin writes, as with
+// skel.NewLogger, to a slog.Handler
+type logForwarder struct {
+	ctx     context.Context
+	handler slog.Handler
+
+	// r and w are the log pipe; the plugin gets a copy of w
+	r, w *os.File
+	done chan struct{}
+}
+
+func newLogForwarder(ctx context.Context, handler slog.Handler, pluginPath string, environ []string) *logForwarder {
+	getenv := protocol.Getenv(environ)
+	return &logForwarder{
+		ctx: ctx,
+		handler: handler.WithAttrs([]slog.Attr{
+			slog.String("plugin", pluginName(filepath.Base(pluginPath))),
+			slog.String("command", getenv("CNI_COMMAND")),
+			slog.String("containerID", getenv("CNI_CONTAINERID")),
+		}),
+	}
+}
+
+// attach passes a log pipe and the log level to the plugin c runs.
+// Platforms that cannot pass extra files only get records from stderr.
+func (f *logForwarder) attach(c *exec.Cmd) error {
+	if c.Env == nil {
+		// A nil environment means the runtime's own
+		c.Env = os.Environ()
+	}
+	// Do not modify the caller's environment slice
+	c.Env = c.Env[:len(c.Env):len(c.Env)]
+	for _, level := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError} {
+		if f.handler.Enabled(f.ctx, level) {
+			c.Env = append(c.Env, EnvLogLevel+"="+level.String())
+			break
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	f.r, f.w = r, w
+	f.done = make(chan struct{})
+	c.ExtraFiles = append(c.ExtraFiles, w)
+	// Extra files start after stdin, stdout and stderr
+	c.Env = append(c.Env, EnvLogFD+"="+strconv.Itoa(2+len(c.ExtraFiles)))
+	go func() {
+		defer close(f.done)
+		lines := &lineWriter{fn: func(line string) { f.forward(line) }}
+		_, _ = io.Copy(lines, r)
+		lines.flush()
+	}()
+	return nil
+}
+
+// wait finishes reading the log pipe after the plugin exited
+func (f *logForwarder) wait() {
+	if f.r == nil {
+		return
+	}
+	// The plugin may not have been started, leaving the write end open
+	f.w.Close()
+	select {
+	case <-f.done:
+	case <-time.After(logDrainTimeout):
+	}
+	f.r.Close()
+	<-f.done
+}
+
+// forward passes line to the handler if it is a log record, returning false
+// if it is not
+func (f *logForwarder) forward(line string) bool {
+	record, ok := parseLogRecord([]byte(line))
+	if !ok {
+		return false
+	}
+	if f.handler.Enabled(f.ctx, record.Level) {
+		_ = f.handler.Handle(f.ctx, record)
+	}
+	return true
+}
+
+// parseLogRecord parses a record written by slog.JSONHandler. Lines that
+// are not JSON objects with a "level" and a "msg" are not records.
+func parseLogRecord(line []byte) (slog.Record, bool) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '{' {
+		return slog.Record{}, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	var fields map[string]interface{}
+	if err := dec.Decode(&fields); err != nil {
+		return slog.Record{}, false
+	}
+
+	levelText, ok := fields[slog.LevelKey].(string)
+	if !ok {
+		return slog.Record{}, false
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(levelText)); err != nil {
+		return slog.Record{}, false
+	}
+	msg, ok := fields[slog.MessageKey].(string)
+	if !ok {
+		return slog.Record{}, false
+	}
+	t := time.Now()
+	if timeText, ok := fields[slog.TimeKey].(string); ok {
+		if parsed, err := time.Parse(time.RFC3339Nano, timeText); err == nil {
+			t = parsed
+		}
+	}
+	delete(fields, slog.LevelKey)
+	delete(fields, slog.MessageKey)
+	delete(fields, slog.TimeKey)
+
+	record := slog.NewRecord(t, level, msg, 0)
+	record.AddAttrs(logAttrs(fields)...)
+	return record, true
+}
+
+// logAttrs converts decoded JSON fields to attributes, sorted by key.
+// Objects become groups.
+func logAttrs(fields map[string]interface{}) []slog.Attr {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		switch v := fields[k].(type) {
+		case map[string]interface{}:
+			attrs = append(attrs, slog.Attr{Key: k, Value: slog.GroupValue(logAttrs(v)...)})
+		case json.Number:
+			if i, err := v.Int64(); err == nil {
+				attrs = append(attrs, slog.Int64(k, i))
+			} else if fl, err := v.Float64(); err == nil {
+				attrs = append(attrs, slog.Float64(k, fl))
+			} else {
+				attrs = append(attrs, slog.String(k, v.String()))
+			}
+		default:
+			attrs = append(attrs, slog.Any(k, v))
+		}
+	}
+	return attrs
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"os/exec"
 	"strings"
 	"time"
@@ -32,20 +33,84 @@ import (
 
 type RawExec struct {
 	Stderr io.Writer
+	// Verifier, if set, checks every plugin before it is executed
+	Verifier *PluginVerifier
+	// Limits, if set, restricts the resources of plugin processes
+	Limits *ProcessLimits
+
+	// MaxStdout and MaxStderr cap how many bytes of the plugin's stdout
+	// and stderr are kept; the rest is discarded. Zero means no limit.
+	// Execution fails if the plugin's stdout exceeds MaxStdout, since the
+	// result would be incomplete.
+	MaxStdout int
+	MaxStderr int
+	// StderrLine, if set, is called with every line the plugin writes to
+	// stderr as it is written, instead of copying stderr to Stderr after
+	// the plugin exits. It is called from a goroutine of its own.
+	StderrLine func(line string)
+	// LogHandler, if set, receives the log records the plugin writes with
+	// a logger from skel.NewLogger, tagged with the "plugin" type,
+	// "command" and "containerID". Records are read from a pipe passed to
+	// the plugin, except on Windows, and from lines of stderr; record lines
+	// on stderr are not passed to StderrLine or copied to Stderr.
+	LogHandler slog.Handler
 }
 
 func (e *RawExec) ExecPlugin(ctx context.Context, pluginPath string, stdinData []byte, environ []string) ([]byte, error) {
-	stdout := &bytes.Buffer{}
-	stderr := &bytes.Buffer{}
+	if e.Verifier != nil {
+		if err := e.Verifier.Verify(pluginPath); err != nil {
+			return nil, err
+		}
+	}
+
+	stdout := &cappedBuffer{max: e.MaxStdout}
+	stderr := &cappedBuffer{max: e.MaxStderr}
 	c := exec.CommandContext(ctx, pluginPath)
 	c.Env = injectTraceContext(ctx, environ)
 	c.Stdin = bytes.NewBuffer(stdinData)
 	c.Stdout = stdout
 	c.Stderr = stderr
 
+	// plain is stderr without log records, for Stderr and errors
+	plain := stderr
+	// lines, if set, splits stderr into lines. Its last line may not end
+	// with a newline, so it is flushed before plain is used.
+	var lines *lineWriter
+	if e.LogHandler != nil {
+		logs := newLogForwarder(ctx, e.LogHandler, pluginPath, c.Env)
+		if err := logs.attach(c); err != nil {
+			return nil, err
+		}
+		defer logs.wait()
+
+		plain = &cappedBuffer{max: e.MaxStderr}
+		lines = &lineWriter{fn: func(line string) {
+			if logs.forward(line) {
+				return
+			}
+			_, _ = plain.Write([]byte(line + "\n"))
+			if e.StderrLine != nil {
+				e.StderrLine(line)
+			}
+		}}
+		c.Stderr = io.MultiWriter(stderr, lines)
+	} else if e.StderrLine != nil {
+		lines = &lineWriter{fn: e.StderrLine}
+		c.Stderr = io.MultiWriter(stderr, lines)
+	}
+
+	var limited *limitedProcess
+	if e.Limits != nil {
+		var err error
+		if limited, err = e.Limits.prepare(c); err != nil {
+			return nil, err
+		}
+		defer limited.cleanup()
+	}
+
 	// Retry the command on "text file busy" errors
 	for i := 0; i <= 5; i++ {
-		err := c.Run()
+		err := runPlugin(c, limited)
 
 		// Command succeeded
 		if err == nil {
@@ -60,18 +125,52 @@ func (e *RawExec) ExecPlugin(ctx context.Context, pluginPath string, stdinData [
 		}
 
 		// All other errors except than the busy text file
-		return nil, e.pluginErr(err, stdout.Bytes(), stderr.Bytes())
+		if lines != nil {
+			lines.flush()
+		}
+		return nil, pluginErr(err, stdout.marked(), plain.marked())
+	}
+	if lines != nil {
+		lines.flush()
 	}
 
 	// Copy stderr to caller's buffer in case plugin printed to both
 	// stdout and stderr for some reason. Ignore failures as stderr is
 	// only informational.
-	if e.Stderr != nil && stderr.Len() > 0 {
-		_, _ = stderr.WriteTo(e.Stderr)
+	if e.StderrLine == nil && e.Stderr != nil && plain.Len() > 0 {
+		_, _ = e.Stderr.Write(plain.marked())
+	}
+	if stdout.truncated > 0 {
+		return nil, fmt.Errorf("plugin %s wrote more than %d bytes to stdout", pluginPath, e.MaxStdout)
 	}
 	return stdout.Bytes(), nil
 }
 
+// runPlugin runs c, applying the resource limits of limited if it is set
+func runPlugin(c *exec.Cmd, limited *limitedProcess) error {
+	if err := c.Start(); err != nil {
+		return err
+	}
+	// The plugin has its own copies of the extra files
+	for _, f := range c.ExtraFiles {
+		f.Close()
+	}
+	if limited == nil {
+		return c.Wait()
+	}
+	if err := limited.started(c.Process.Pid); err != nil {
+		// Never let the plugin run unrestricted
+		if c.Cancel != nil {
+			_ = c.Cancel()
+		} else {
+			_ = c.Process.Kill()
+		}
+		_ = c.Wait()
+		return err
+	}
+	return c.Wait()
+}
+
 // injectTraceContext will add OpenTelemetry trace context to the environment variables based on
 // https://github.com/open-telemetry/opentelemetry-specification/blob/main/oteps/0258-env-context-baggage-carriers.md
 func injectTraceContext(ctx context.Context, environ []string) []string {
@@ -101,7 +200,7 @@ func injectTraceContext(ctx context.Context, environ []string) []string {
 	return environ
 }
 
-func (e *RawExec) pluginErr(err error, stdout, stderr []byte) error {
+func pluginErr(err error, stdout, stderr []byte) error {
 	emsg := types.Error{}
 	if len(stdout) == 0 {
 		if len(stderr) == 0 {
@@ -17,7 +17,12 @@ package invoke_test
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -101,6 +106,184 @@ var _ = Describe("RawExec", func() {
 		})
 	})
 
+	Context("when output is capped", func() {
+		It("fails if stdout exceeds the cap", func() {
+			execer.MaxStdout = 5
+			_, err := execer.ExecPlugin(ctx, pathToPlugin, stdin, environ)
+			Expect(err).To(MatchError(fmt.Sprintf("plugin %s wrote more than 5 bytes to stdout", pathToPlugin)))
+
+			execer.MaxStdout = len(reportResult)
+			resultBytes, err := execer.ExecPlugin(ctx, pathToPlugin, stdin, environ)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resultBytes).To(BeEquivalentTo(reportResult))
+		})
+
+		It("marks truncated output in error messages", func() {
+			execer.MaxStderr = 4
+			debug.ReportResult = ""
+			debug.ExitWithCode = 1
+			Expect(debug.WriteDebug(debugFileName)).To(Succeed())
+			_, err := execer.ExecPlugin(ctx, pathToPlugin, stdin, environ)
+			Expect(err).To(MatchError(`netplugin failed: "some... [15 bytes truncated]": exit status 1`))
+
+			execer.MaxStdout = 10
+			debug.ReportError = "banana"
+			Expect(debug.WriteDebug(debugFileName)).To(Succeed())
+			_, err = execer.ExecPlugin(ctx, pathToPlugin, stdin, environ)
+			Expect(err).To(MatchError(ContainSubstring("netplugin failed but error parsing its diagnostic message")))
+			Expect(err).To(MatchError(ContainSubstring("bytes truncated]")))
+		})
+
+		It("marks truncated stderr copied to the Stderr writer", func() {
+			stderrBuffer := &bytes.Buffer{}
+			execer.Stderr = stderrBuffer
+			execer.MaxStderr = 4
+			_, err := execer.ExecPlugin(ctx, pathToPlugin, stdin, environ)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stderrBuffer.String()).To(Equal("some... [15 bytes truncated]"))
+		})
+	})
+
+	Context("when StderrLine is set", func() {
+		var (
+			lines        []string
+			stderrBuffer *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			lines = nil
+			stderrBuffer = &bytes.Buffer{}
+			execer.Stderr = stderrBuffer
+			execer.StderrLine = func(line string) {
+				lines = append(lines, line)
+			}
+		})
+
+		It("passes stderr on line by line instead of copying it", func() {
+			debug.ReportStderr = "first\nsecond\r\n\nlast"
+			Expect(debug.WriteDebug(debugFileName)).To(Succeed())
+			_, err := execer.ExecPlugin(ctx, pathToPlugin, stdin, environ)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lines).To(Equal([]string{"first", "second", "", "last"}))
+			Expect(stderrBuffer.Len()).To(BeZero())
+		})
+
+		It("splits very long lines", func() {
+			debug.ReportStderr = strings.Repeat("x", 70000)
+			Expect(debug.WriteDebug(debugFileName)).To(Succeed())
+			_, err := execer.ExecPlugin(ctx, pathToPlugin, stdin, environ)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lines).To(HaveLen(2))
+			Expect(lines[0]).To(HaveLen(64 * 1024))
+			Expect(lines[1]).To(HaveLen(70000 - 64*1024))
+		})
+
+		It("still reports stderr in error messages", func() {
+			debug.ReportResult = ""
+			debug.ExitWithCode = 1
+			Expect(debug.WriteDebug(debugFileName)).To(Succeed())
+			_, err := execer.ExecPlugin(ctx, pathToPlugin, stdin, environ)
+			Expect(err).To(MatchError(`netplugin failed: "some stderr message": exit status 1`))
+			Expect(lines).To(Equal([]string{"some stderr message"}))
+		})
+	})
+
+	Context("when LogHandler is set", func() {
+		var (
+			logs         *bytes.Buffer
+			stderrBuffer *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			logs = &bytes.Buffer{}
+			stderrBuffer = &bytes.Buffer{}
+			execer.Stderr = stderrBuffer
+			execer.LogHandler = slog.NewJSONHandler(logs, &slog.HandlerOptions{
+				ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+					if a.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+					return a
+				},
+			})
+		})
+
+		It("forwards the plugin's log records with its type, command and container ID", func() {
+			debug.ReportStderr = ""
+			debug.ReportLog = "configuring"
+			Expect(debug.WriteDebug(debugFileName)).To(Succeed())
+			_, err := execer.ExecPlugin(ctx, pathToPlugin, stdin, environ)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logs.String()).To(MatchJSON(fmt.Sprintf(`{
+				"level": "INFO",
+				"msg": "configuring",
+				"plugin": %q,
+				"command": "ADD",
+				"containerID": "some-container-id",
+				"attempt": 1,
+				"iface": {"name": "some-eth0"}
+			}`, filepath.Base(pathToPlugin))))
+			Expect(stderrBuffer.Len()).To(BeZero())
+		})
+
+		It("reads log records from a pipe rather than stderr", func() {
+			if runtime.GOOS == "windows" {
+				Skip("Windows plugins log to stderr")
+			}
+			debug.ReportStderr = ""
+			debug.ReportResult = ""
+			debug.ReportLog = "configuring"
+			debug.ExitWithCode = 1
+			Expect(debug.WriteDebug(debugFileName)).To(Succeed())
+			_, err := execer.ExecPlugin(ctx, pathToPlugin, stdin, environ)
+			Expect(err).To(MatchError("netplugin failed with no error message: exit status 1"))
+			Expect(logs.String()).To(ContainSubstring(`"msg":"configuring"`))
+		})
+
+		It("passes the handler's level to the plugin", func() {
+			execer.LogHandler = slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug})
+			debug.ReportLog = "configuring"
+			Expect(debug.WriteDebug(debugFileName)).To(Succeed())
+			_, err := execer.ExecPlugin(ctx, pathToPlugin, stdin, environ)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.Count(logs.String(), "\n")).To(Equal(2))
+			Expect(logs.String()).To(ContainSubstring(`"level":"DEBUG"`))
+		})
+
+		It("forwards log records written to stderr", func() {
+			debug.ReportStderr = `{"level": "WARN", "msg": "from stderr", "count": 2.5}` + "\nplain text\n"
+			Expect(debug.WriteDebug(debugFileName)).To(Succeed())
+			_, err := execer.ExecPlugin(ctx, pathToPlugin, stdin, environ)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logs.String()).To(MatchJSON(fmt.Sprintf(`{
+				"level": "WARN",
+				"msg": "from stderr",
+				"plugin": %q,
+				"command": "ADD",
+				"containerID": "some-container-id",
+				"count": 2.5
+			}`, filepath.Base(pathToPlugin))))
+			Expect(stderrBuffer.String()).To(Equal("plain text\n"))
+		})
+
+		It("keeps a last plain line that does not end with a newline", func() {
+			debug.ReportStderr = `{"level": "WARN", "msg": "from stderr"}` + "\nplain text"
+			Expect(debug.WriteDebug(debugFileName)).To(Succeed())
+			_, err := execer.ExecPlugin(ctx, pathToPlugin, stdin, environ)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logs.String()).To(ContainSubstring(`"msg":"from stderr"`))
+			Expect(stderrBuffer.String()).To(Equal("plain text\n"))
+
+			debug.ReportResult = ""
+			debug.ExitWithCode = 1
+			Expect(debug.WriteDebug(debugFileName)).To(Succeed())
+			_, err = execer.ExecPlugin(ctx, pathToPlugin, stdin, environ)
+			Expect(err).To(MatchError(`netplugin failed: "plain text\n": exit status 1`))
+		})
+	})
+
 	Context("when the plugin errors", func() {
 		BeforeEach(func() {
 			debug.ReportResult = ""
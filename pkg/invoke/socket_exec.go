@@ -0,0 +1,120 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invoke
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/containernetworking/cni/internal/protocol"
+	"github.com/containernetworking/cni/pkg/version"
+)
+
+// SocketRequest is a CNI request sent to a plugin served over a socket. It
+// carries what a plugin binary receives: its environment, in the form
+// returned by Args.AsEnv, and its stdin.
+type SocketRequest = protocol.SocketRequest
+
+// SocketResponse is a served plugin's answer to a SocketRequest
+type SocketResponse = protocol.SocketResponse
+
+// SocketExec is an Exec that sends requests for some plugins to daemons
+// serving the CNI protocol on Unix sockets, see skel.Server, instead of
+// executing binaries. libcni uses one for plugin configurations setting the
+// "cni.dev/socket" key.
+type SocketExec struct {
+	// Sockets maps plugin types to the socket their daemon listens on
+	Sockets map[string]string
+	// Fallback, if set, finds and executes plugins not in Sockets
+	Fallback Exec
+	// Stderr receives what plugins write to their stderr. If nil, it is
+	// discarded.
+	Stderr io.Writer
+
+	version.PluginDecoder
+}
+
+// SocketExec implements the Exec interface
+var _ Exec = &SocketExec{}
+
+// FindInPath returns the plugin name itself for plugins served on a socket,
+// which ExecPlugin then sends the request for. Since names never contain a
+// path separator, they cannot be confused with paths returned by Fallback.
+func (e *SocketExec) FindInPath(plugin string, paths []string) (string, error) {
+	if _, ok := e.Sockets[plugin]; ok {
+		return plugin, nil
+	}
+	if e.Fallback != nil {
+		return e.Fallback.FindInPath(plugin, paths)
+	}
+	return "", fmt.Errorf("failed to find plugin %q in path %s", plugin, paths)
+}
+
+// ExecPlugin sends the request to the socket of the plugin named
+// pluginPath, or passes the call to Fallback for any other path
+func (e *SocketExec) ExecPlugin(ctx context.Context, pluginPath string, stdinData []byte, environ []string) ([]byte, error) {
+	socket, ok := e.Sockets[pluginPath]
+	if !ok {
+		if e.Fallback != nil {
+			return e.Fallback.ExecPlugin(ctx, pluginPath, stdinData, environ)
+		}
+		return nil, fmt.Errorf("no socket configured for plugin %q", pluginPath)
+	}
+
+	resp, err := sendSocketRequest(ctx, socket, &SocketRequest{
+		Env:   injectTraceContext(ctx, environ),
+		Stdin: stdinData,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("plugin %q at %s: %w", pluginPath, socket, err)
+	}
+	if resp.Failed {
+		return nil, pluginErr(errors.New("plugin reported failure"), resp.Stdout, resp.Stderr)
+	}
+	// As with RawExec, stderr is only informational
+	if e.Stderr != nil && len(resp.Stderr) > 0 {
+		_, _ = e.Stderr.Write(resp.Stderr)
+	}
+	return resp.Stdout, nil
+}
+
+func sendSocketRequest(ctx context.Context, socket string, req *SocketRequest) (*SocketResponse, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", socket)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	// Unblock reads and writes when the context ends
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	resp := &SocketResponse{}
+	err = json.NewEncoder(conn).Encode(req)
+	if err == nil {
+		err = json.NewDecoder(conn).Decode(resp)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invoke_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/pkg/invoke"
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/containernetworking/cni/pkg/types"
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/cni/pkg/version"
+)
+
+var _ = Describe("SocketExec", func() {
+	var (
+		server     *skel.Server
+		socketPath string
+		exec       *invoke.SocketExec
+		received   chan *skel.CmdArgs
+		release    chan struct{}
+		netconf    []byte
+		args       *invoke.Args
+		ctx        context.Context
+	)
+
+	BeforeEach(func() {
+		dir, err := os.MkdirTemp("", "cni-sock")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		socketPath = filepath.Join(dir, "daemon.sock")
+
+		received = make(chan *skel.CmdArgs, 1)
+		release = make(chan struct{})
+		server = &skel.Server{
+			Funcs: skel.CNIFuncs{
+				Add: func(args *skel.CmdArgs) error {
+					received <- args
+					return args.PrintResult(&current.Result{CNIVersion: "1.0.0", Interfaces: []*current.Interface{{Name: "eth0"}}}, "1.0.0")
+				},
+				Del: func(*skel.CmdArgs) error {
+					return types.NewError(types.ErrTryAgainLater, "busy", "try again")
+				},
+				Check: func(*skel.CmdArgs) error {
+					<-release
+					return nil
+				},
+			},
+			VersionInfo: version.PluginSupports("0.4.0", "1.0.0"),
+		}
+		l, err := net.Listen("unix", socketPath)
+		Expect(err).NotTo(HaveOccurred())
+		go func() {
+			defer GinkgoRecover()
+			Expect(server.Serve(l)).To(Equal(skel.ErrServerClosed))
+		}()
+		DeferCleanup(server.Close)
+		DeferCleanup(func() { close(release) })
+
+		exec = &invoke.SocketExec{
+			Sockets: map[string]string{"shim": socketPath},
+		}
+		netconf = []byte(`{"cniVersion": "1.0.0", "name": "net", "type": "shim"}`)
+		args = &invoke.Args{
+			Command:     "ADD",
+			ContainerID: "some-container-id",
+			NetNS:       "/some/netns",
+			IfName:      "eth0",
+			Path:        "/some/bin",
+		}
+		ctx = context.TODO()
+	})
+
+	It("sends requests to the plugin's socket", func() {
+		path, err := exec.FindInPath("shim", []string{"/some/bin"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal("shim"))
+
+		result, err := invoke.ExecPluginWithResult(ctx, path, netconf, args, exec)
+		Expect(err).NotTo(HaveOccurred())
+		res, err := current.NewResultFromResult(result)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Interfaces[0].Name).To(Equal("eth0"))
+
+		var cmdArgs *skel.CmdArgs
+		Expect(received).To(Receive(&cmdArgs))
+		Expect(cmdArgs.ContainerID).To(Equal("some-container-id"))
+		Expect(cmdArgs.Netns).To(Equal("/some/netns"))
+		Expect(cmdArgs.StdinData).To(MatchJSON(netconf))
+
+		vi, err := invoke.GetVersionInfo(ctx, path, exec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vi.SupportedVersions()).To(Equal([]string{"0.4.0", "1.0.0"}))
+	})
+
+	It("returns plugin errors", func() {
+		args.Command = "DEL"
+		err := invoke.ExecPluginWithoutResult(ctx, "shim", netconf, args, exec)
+		Expect(err).To(Equal(&types.Error{Code: types.ErrTryAgainLater, Msg: "busy", Details: "try again"}))
+	})
+
+	It("stops waiting when the context ends", func() {
+		args.Command = "CHECK"
+		timeout, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		err := invoke.ExecPluginWithoutResult(timeout, "shim", netconf, args, exec)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	It("reports unreachable sockets", func() {
+		exec.Sockets["gone"] = filepath.Join(filepath.Dir(socketPath), "gone.sock")
+		_, err := invoke.ExecPluginWithResult(ctx, "gone", netconf, args, exec)
+		Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf(`plugin "gone" at %s`, exec.Sockets["gone"]))))
+	})
+
+	It("passes other plugins to the fallback", func() {
+		_, err := exec.FindInPath("other", []string{"/some/bin"})
+		Expect(err).To(MatchError(`failed to find plugin "other" in path [/some/bin]`))
+
+		exec.Fallback = &fallbackExec{}
+		path, err := exec.FindInPath("other", []string{"/some/bin"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = exec.ExecPlugin(ctx, path, netconf, nil)
+		Expect(err).To(MatchError("fallback executed " + path))
+	})
+})
+
+type fallbackExec struct {
+	version.PluginDecoder
+}
+
+func (f *fallbackExec) ExecPlugin(_ context.Context, pluginPath string, _ []byte, _ []string) ([]byte, error) {
+	return nil, errors.New("fallback executed " + pluginPath)
+}
+
+func (f *fallbackExec) FindInPath(plugin string, paths []string) (string, error) {
+	if len(paths) == 0 {
+		return "", fmt.Errorf("no paths provided")
+	}
+	return filepath.Join(paths[0], plugin), nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invoke
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/containernetworking/cni/internal/integrity"
+)
+
+// PluginVerifier checks plugin binaries before RawExec executes them. The
+// zero value checks nothing; enable checks by setting its fields before
+// first use.
+//
+// Checks protect against plugins that were replaced or tampered with before
+// execution. A plugin replaced between the check and its execution is not
+// detected, which is why CheckPermissions also checks the plugin's
+// directory.
+type PluginVerifier struct {
+	// Digests allowlists plugins by the hex-encoded SHA-256 digest of their
+	// contents, keyed by the plugin's file name. A plugin whose name is not
+	// listed is rejected. See ParseDigestAllowlist.
+	Digests map[string][]string
+	// PublicKeys, if set, requires every plugin to have a signature file
+	// named after it with the ".sig" extension, holding the ed25519
+	// signature of its contents by one of the keys, raw or base64-encoded.
+	PublicKeys []ed25519.PublicKey
+	// CheckPermissions rejects plugins that are writable by others, or that
+	// are not owned by one of AllowedOwners, and the same for the directory
+	// containing them. For plugins that are symlinks, both the target and
+	// its directory are checked, along with the directory of the link. It
+	// is not supported on Windows, where it is ignored.
+	CheckPermissions bool
+	// AllowedOwners are the user IDs that may own plugins and their
+	// directory. It defaults to root only.
+	AllowedOwners []int
+
+	mu sync.Mutex
+	// verified records the plugins that passed the digest and signature
+	// checks, so that they are only hashed again if they change
+	verified map[string]integrity.FileID
+}
+
+// PluginVerificationError is returned for plugins that fail verification
+type PluginVerificationError struct {
+	Path string
+	Err  error
+}
+
+func (e *PluginVerificationError) Error() string {
+	return fmt.Sprintf("plugin %s failed verification: %v", e.Path, e.Err)
+}
+
+func (e *PluginVerificationError) Unwrap() error {
+	return e.Err
+}
+
+// ParseDigestAllowlist parses an allowlist in the format written by
+// sha256sum, a digest and a file name per line, for PluginVerifier.Digests.
+// Only the base name of each file is used. Empty lines and lines starting
+// with "#" are ignored.
+func ParseDigestAllowlist(data []byte) (map[string][]string, error) {
+	digests := map[string][]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected a digest and a file name", line)
+		}
+		digest := strings.ToLower(fields[0])
+		if !integrity.IsDigest(digest) {
+			return nil, fmt.Errorf("line %d: invalid SHA-256 digest %q", line, fields[0])
+		}
+		// sha256sum marks binary mode with a "*" before the file name
+		name := filepath.Base(strings.TrimPrefix(fields[1], "*"))
+		digests[name] = append(digests[name], digest)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return digests, nil
+}
+
+// Verify checks a plugin binary
+func (v *PluginVerifier) Verify(pluginPath string) error {
+	if err := v.verify(pluginPath); err != nil {
+		return &PluginVerificationError{Path: pluginPath, Err: err}
+	}
+	return nil
+}
+
+func (v *PluginVerifier) verify(pluginPath string) error {
+	fi, err := os.Stat(pluginPath)
+	if err != nil {
+		return err
+	}
+	if v.CheckPermissions && permissionChecksSupported {
+		if err := v.checkPluginPermissions(pluginPath); err != nil {
+			return err
+		}
+	}
+
+	if v.Digests == nil && len(v.PublicKeys) == 0 {
+		return nil
+	}
+	id := integrity.StatFileID(fi)
+	v.mu.Lock()
+	cached, ok := v.verified[pluginPath]
+	v.mu.Unlock()
+	if ok && cached == id {
+		return nil
+	}
+
+	contents, err := os.ReadFile(pluginPath)
+	if err != nil {
+		return err
+	}
+	if v.Digests != nil {
+		if err := v.checkDigest(pluginPath, contents); err != nil {
+			return err
+		}
+	}
+	if len(v.PublicKeys) > 0 {
+		if err := v.checkSignature(pluginPath, contents); err != nil {
+			return err
+		}
+	}
+
+	v.mu.Lock()
+	if v.verified == nil {
+		v.verified = map[string]integrity.FileID{}
+	}
+	v.verified[pluginPath] = id
+	v.mu.Unlock()
+	return nil
+}
+
+// checkPluginPermissions checks the plugin and the directory containing
+// it. Symlinks are resolved first, so that a link in a trusted directory
+// cannot point at a binary others can replace. The directory holding the
+// link is checked as well, since the link itself could be replaced.
+func (v *PluginVerifier) checkPluginPermissions(pluginPath string) error {
+	target, err := filepath.EvalSymlinks(pluginPath)
+	if err != nil {
+		return err
+	}
+	paths := []string{target, filepath.Dir(target)}
+	if dir := filepath.Dir(pluginPath); dir != paths[1] {
+		paths = append(paths, dir)
+	}
+	for _, path := range paths {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if err := v.checkPermissions(path, fi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *PluginVerifier) checkPermissions(path string, fi os.FileInfo) error {
+	if fi.Mode().Perm()&0o002 != 0 {
+		return fmt.Errorf("%s is writable by others", path)
+	}
+	uid, ok := fileOwner(fi)
+	if !ok {
+		return nil
+	}
+	owners := v.AllowedOwners
+	if owners == nil {
+		owners = []int{0}
+	}
+	for _, o := range owners {
+		if o == uid {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is owned by user %d", path, uid)
+}
+
+func (v *PluginVerifier) checkDigest(pluginPath string, contents []byte) error {
+	name := filepath.Base(pluginPath)
+	allowed, ok := v.Digests[name]
+	if !ok {
+		return fmt.Errorf("%s is not in the digest allowlist", name)
+	}
+	digest := integrity.Digest(contents)
+	for _, d := range allowed {
+		if d == digest {
+			return nil
+		}
+	}
+	return fmt.Errorf("digest %s of %s is not in the allowlist", digest, name)
+}
+
+func (v *PluginVerifier) checkSignature(pluginPath string, contents []byte) error {
+	sig, err := os.ReadFile(pluginPath + ".sig")
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("missing signature file %s.sig", pluginPath)
+	} else if err != nil {
+		return err
+	}
+	return integrity.VerifySignature(contents, sig, v.PublicKeys)
+}
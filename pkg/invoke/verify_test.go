@@ -0,0 +1,226 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invoke_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/pkg/invoke"
+)
+
+var _ = Describe("PluginVerifier", func() {
+	var (
+		dir      string
+		plugin   string
+		contents []byte
+		verifier *invoke.PluginVerifier
+	)
+
+	digestOf := func(data []byte) string {
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])
+	}
+
+	BeforeEach(func() {
+		// Resolved, since permission errors name the resolved paths
+		var err error
+		dir, err = filepath.EvalSymlinks(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+		contents = []byte("#!/bin/sh\necho '{}'\n")
+		plugin = filepath.Join(dir, "bridge")
+		Expect(os.WriteFile(plugin, contents, 0o755)).To(Succeed())
+		verifier = &invoke.PluginVerifier{}
+	})
+
+	It("checks nothing by default", func() {
+		Expect(verifier.Verify(plugin)).To(Succeed())
+		Expect(verifier.Verify(filepath.Join(dir, "missing"))).To(MatchError(os.ErrNotExist))
+	})
+
+	Describe("digest allowlists", func() {
+		It("parses sha256sum output", func() {
+			digests, err := invoke.ParseDigestAllowlist([]byte(fmt.Sprintf(
+				"# plugins\n%s  /opt/cni/bin/bridge\n\n%s *ptp\n%s  bridge\n",
+				digestOf([]byte("a")), digestOf([]byte("b")), digestOf([]byte("c")))))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(digests).To(Equal(map[string][]string{
+				"bridge": {digestOf([]byte("a")), digestOf([]byte("c"))},
+				"ptp":    {digestOf([]byte("b"))},
+			}))
+
+			_, err = invoke.ParseDigestAllowlist([]byte("abc bridge\n"))
+			Expect(err).To(MatchError(`line 1: invalid SHA-256 digest "abc"`))
+			_, err = invoke.ParseDigestAllowlist([]byte("\nabc\n"))
+			Expect(err).To(MatchError("line 2: expected a digest and a file name"))
+		})
+
+		It("accepts listed plugins and rejects others", func() {
+			verifier.Digests = map[string][]string{"bridge": {digestOf(contents)}}
+			Expect(verifier.Verify(plugin)).To(Succeed())
+
+			other := filepath.Join(dir, "ptp")
+			Expect(os.WriteFile(other, contents, 0o755)).To(Succeed())
+			err := verifier.Verify(other)
+			Expect(err).To(MatchError(fmt.Sprintf("plugin %s failed verification: ptp is not in the digest allowlist", other)))
+			var verr *invoke.PluginVerificationError
+			Expect(errors.As(err, &verr)).To(BeTrue())
+			Expect(verr.Path).To(Equal(other))
+
+			Expect(os.WriteFile(plugin, []byte("#!/bin/sh\nevil\n"), 0o755)).To(Succeed())
+			Expect(verifier.Verify(plugin)).To(MatchError(ContainSubstring("of bridge is not in the allowlist")))
+		})
+
+		It("only hashes plugins again when they change", func() {
+			verifier.Digests = map[string][]string{"bridge": {digestOf(contents)}}
+			Expect(verifier.Verify(plugin)).To(Succeed())
+
+			// Still accepted, since the verified file has not changed
+			verifier.Digests["bridge"] = nil
+			Expect(verifier.Verify(plugin)).To(Succeed())
+
+			later := time.Now().Add(time.Minute)
+			Expect(os.Chtimes(plugin, later, later)).To(Succeed())
+			Expect(verifier.Verify(plugin)).To(MatchError(ContainSubstring("is not in the allowlist")))
+		})
+	})
+
+	Describe("signatures", func() {
+		var (
+			pub  ed25519.PublicKey
+			priv ed25519.PrivateKey
+		)
+
+		BeforeEach(func() {
+			var err error
+			pub, priv, err = ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+			verifier.PublicKeys = []ed25519.PublicKey{pub}
+		})
+
+		It("accepts signed plugins", func() {
+			sig := base64.StdEncoding.EncodeToString(ed25519.Sign(priv, contents))
+			Expect(os.WriteFile(plugin+".sig", []byte(sig+"\n"), 0o644)).To(Succeed())
+			Expect(verifier.Verify(plugin)).To(Succeed())
+		})
+
+		It("rejects unsigned and wrongly signed plugins", func() {
+			Expect(verifier.Verify(plugin)).To(MatchError(ContainSubstring("missing signature file " + plugin + ".sig")))
+
+			Expect(os.WriteFile(plugin+".sig", ed25519.Sign(priv, []byte("other")), 0o644)).To(Succeed())
+			Expect(verifier.Verify(plugin)).To(MatchError(ContainSubstring("signature does not match any public key")))
+
+			Expect(os.WriteFile(plugin+".sig", []byte("!!"), 0o644)).To(Succeed())
+			Expect(verifier.Verify(plugin)).To(MatchError(ContainSubstring("invalid signature encoding")))
+		})
+	})
+
+	Describe("permissions", func() {
+		BeforeEach(func() {
+			if runtime.GOOS == "windows" {
+				Skip("permissions are not checked on Windows")
+			}
+			verifier.CheckPermissions = true
+			verifier.AllowedOwners = []int{os.Getuid()}
+		})
+
+		It("accepts plugins owned by an allowed user", func() {
+			Expect(verifier.Verify(plugin)).To(Succeed())
+		})
+
+		It("rejects plugins owned by other users", func() {
+			verifier.AllowedOwners = []int{os.Getuid() + 1}
+			Expect(verifier.Verify(plugin)).To(MatchError(ContainSubstring(fmt.Sprintf("%s is owned by user %d", plugin, os.Getuid()))))
+		})
+
+		It("rejects plugins and directories writable by others", func() {
+			Expect(os.Chmod(plugin, 0o757)).To(Succeed())
+			Expect(verifier.Verify(plugin)).To(MatchError(ContainSubstring(plugin + " is writable by others")))
+
+			Expect(os.Chmod(plugin, 0o755)).To(Succeed())
+			Expect(os.Chmod(dir, 0o777)).To(Succeed())
+			Expect(verifier.Verify(plugin)).To(MatchError(ContainSubstring(dir + " is writable by others")))
+		})
+
+		It("checks the target of symlinks and its directory", func() {
+			shared := filepath.Join(dir, "shared")
+			Expect(os.Mkdir(shared, 0o755)).To(Succeed())
+			target := filepath.Join(shared, "bridge")
+			Expect(os.WriteFile(target, contents, 0o755)).To(Succeed())
+			trusted := filepath.Join(dir, "bin")
+			Expect(os.Mkdir(trusted, 0o755)).To(Succeed())
+			link := filepath.Join(trusted, "bridge")
+			Expect(os.Symlink(target, link)).To(Succeed())
+			Expect(verifier.Verify(link)).To(Succeed())
+
+			// Anyone could replace the binary the link points at
+			Expect(os.Chmod(shared, 0o777)).To(Succeed())
+			Expect(verifier.Verify(link)).To(MatchError(ContainSubstring(shared + " is writable by others")))
+
+			Expect(os.Chmod(shared, 0o755)).To(Succeed())
+			Expect(os.Chmod(target, 0o757)).To(Succeed())
+			Expect(verifier.Verify(link)).To(MatchError(ContainSubstring(target + " is writable by others")))
+
+			// Anyone could replace the link itself
+			Expect(os.Chmod(target, 0o755)).To(Succeed())
+			Expect(os.Chmod(trusted, 0o777)).To(Succeed())
+			Expect(verifier.Verify(link)).To(MatchError(ContainSubstring(trusted + " is writable by others")))
+		})
+	})
+
+	Describe("with RawExec", func() {
+		var (
+			execer  *invoke.RawExec
+			environ []string
+		)
+
+		BeforeEach(func() {
+			data, err := os.ReadFile(pathToPlugin)
+			Expect(err).NotTo(HaveOccurred())
+			plugin = filepath.Join(dir, "noop")
+			Expect(os.WriteFile(plugin, data, 0o755)).To(Succeed())
+
+			verifier.Digests = map[string][]string{"noop": {digestOf(data)}}
+			execer = &invoke.RawExec{Verifier: verifier}
+			environ = []string{"CNI_COMMAND=VERSION"}
+		})
+
+		It("runs plugins that pass verification", func() {
+			out, err := execer.ExecPlugin(context.TODO(), plugin, []byte(`{"cniVersion": "1.0.0"}`), environ)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("supportedVersions"))
+		})
+
+		It("does not run plugins that fail verification", func() {
+			Expect(os.WriteFile(plugin, []byte("#!/bin/sh\ntouch "+filepath.Join(dir, "ran")+"\n"), 0o755)).To(Succeed())
+			_, err := execer.ExecPlugin(context.TODO(), plugin, []byte(`{"cniVersion": "1.0.0"}`), environ)
+			Expect(err).To(BeAssignableToTypeOf(&invoke.PluginVerificationError{}))
+			Expect(filepath.Join(dir, "ran")).NotTo(BeAnExistingFile())
+		})
+	})
+})
@@ -0,0 +1,113 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skel
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/containernetworking/cni/internal/protocol"
+)
+
+const (
+	// EnvLogFD names the environment variable holding the file descriptor
+	// a plugin writes its log records to, see invoke.EnvLogFD
+	EnvLogFD = protocol.EnvLogFD
+	// EnvLogLevel names the environment variable holding the lowest level
+	// of log records the runtime is interested in, see invoke.EnvLogLevel
+	EnvLogLevel = protocol.EnvLogLevel
+)
+
+// NewLogger returns a logger for plugins that writes JSON log records, one
+// per line, which the runtime can forward to its own logger. Each record
+// has "time", "level" and "msg" keys and a key for every attribute.
+// Records are written to the file descriptor in CNI_LOG_FD if it is set,
+// and to stderr otherwise. Unless opts sets a level, records below the
+// level in CNI_LOG_LEVEL, or INFO if unset, are dropped. opts may be nil.
+//
+// NewLogger reads the process's environment. Plugins that may run
+// in-process or be served by a Server should use CmdArgs.Logger instead.
+func NewLogger(opts *slog.HandlerOptions) *slog.Logger {
+	return newLogger(os.Getenv(EnvLogFD), os.Getenv(EnvLogLevel), os.Stderr, opts)
+}
+
+// Logger is like NewLogger, but takes CNI_LOG_FD and CNI_LOG_LEVEL from the
+// request's environment, and writes to the request's stderr if CNI_LOG_FD
+// is not set.
+func (args *CmdArgs) Logger(opts *slog.HandlerOptions) *slog.Logger {
+	stderr := args.stderr
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+	return newLogger(args.logFD, args.logLevel, stderr, opts)
+}
+
+func newLogger(logFD, logLevel string, stderr io.Writer, opts *slog.HandlerOptions) *slog.Logger {
+	var o slog.HandlerOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Level == nil {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(logLevel)); err == nil {
+			o.Level = level
+		}
+	}
+	w := stderr
+	if f := logFile(logFD); f != nil {
+		w = f
+	}
+	return slog.New(slog.NewJSONHandler(w, &o))
+}
+
+// logFiles holds the files opened for log file descriptors. Each is only
+// opened once, since the garbage collector closes the descriptor of an
+// unreachable *os.File, which would break other loggers using it.
+var logFiles struct {
+	sync.Mutex
+	fds map[int]*logFileOnce
+}
+
+type logFileOnce struct {
+	once sync.Once
+	file *os.File
+}
+
+// logFile returns the file for the descriptor in logFD, or nil if there
+// is none
+func logFile(logFD string) *os.File {
+	fd, err := strconv.Atoi(logFD)
+	if err != nil || fd <= 2 {
+		return nil
+	}
+	logFiles.Lock()
+	if logFiles.fds == nil {
+		logFiles.fds = map[int]*logFileOnce{}
+	}
+	lf, ok := logFiles.fds[fd]
+	if !ok {
+		lf = &logFileOnce{}
+		logFiles.fds[fd] = lf
+	}
+	logFiles.Unlock()
+
+	lf.once.Do(func() {
+		lf.file = os.NewFile(uintptr(fd), "cni-log")
+	})
+	return lf.file
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skel
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/containernetworking/cni/internal/protocol"
+	"github.com/containernetworking/cni/pkg/types"
+	"github.com/containernetworking/cni/pkg/version"
+)
+
+// ErrServerClosed is returned by Serve and ListenAndServe after Close
+var ErrServerClosed = errors.New("skel: server closed")
+
+// Server serves the CNI protocol for a plugin over Unix sockets, so that a
+// long-running daemon can answer requests that would otherwise execute a
+// plugin binary. Every connection carries one protocol.SocketRequest and its
+// protocol.SocketResponse, both JSON encoded. Requests go through the same
+// environment parsing, version checks and dispatch as PluginMainFuncs.
+//
+// Requests are served concurrently, so Funcs must be safe for concurrent
+// use. Like plugins run in-process, they must write their results with
+// CmdArgs.PrintResult or to CmdArgs.Stdout.
+type Server struct {
+	Funcs       CNIFuncs
+	VersionInfo version.PluginInfo
+
+	mu        sync.Mutex
+	closed    bool
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	wg        sync.WaitGroup
+}
+
+// ListenAndServe listens on the Unix socket at socketPath and serves
+// requests on it. A socket left over at socketPath is removed first.
+func (s *Server) ListenAndServe(socketPath string) error {
+	if fi, err := os.Lstat(socketPath); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(socketPath); err != nil {
+			return err
+		}
+	}
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// Serve accepts connections on l and serves one request on each. It always
+// returns an error, ErrServerClosed after Close. l is closed on return.
+func (s *Server) Serve(l net.Listener) error {
+	if !s.track(l, nil) {
+		l.Close()
+		return ErrServerClosed
+	}
+	defer s.untrack(l, nil)
+	defer l.Close()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if s.isClosed() {
+				return ErrServerClosed
+			}
+			return err
+		}
+		if !s.track(nil, conn) {
+			conn.Close()
+			return ErrServerClosed
+		}
+		go func() {
+			defer s.untrack(nil, conn)
+			defer conn.Close()
+			s.serveConn(conn)
+		}()
+	}
+}
+
+// Close stops all listeners, closes open connections and waits for
+// requests in progress to return
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	var err error
+	for l := range s.listeners {
+		if cerr := l.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	for c := range s.conns {
+		c.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// track registers a listener or connection, returning false if the server
+// is closed
+func (s *Server) track(l net.Listener, c net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	if l != nil {
+		if s.listeners == nil {
+			s.listeners = map[net.Listener]struct{}{}
+		}
+		s.listeners[l] = struct{}{}
+	}
+	if c != nil {
+		if s.conns == nil {
+			s.conns = map[net.Conn]struct{}{}
+		}
+		s.conns[c] = struct{}{}
+	}
+	s.wg.Add(1)
+	return true
+}
+
+func (s *Server) untrack(l net.Listener, c net.Conn) {
+	s.mu.Lock()
+	delete(s.listeners, l)
+	delete(s.conns, c)
+	s.mu.Unlock()
+	s.wg.Done()
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	var req protocol.SocketRequest
+	var resp *protocol.SocketResponse
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		resp = failedResponse(types.NewError(types.ErrDecodingFailure, fmt.Sprintf("error decoding request: %v", err), ""))
+	} else {
+		resp = s.handle(&req)
+	}
+	// The client may be gone; there is nobody to report a failure to
+	_ = json.NewEncoder(conn).Encode(resp)
+}
+
+// handle runs the plugin for req, turning a panic into a failure as a
+// crashing plugin binary would be
+func (s *Server) handle(req *protocol.SocketRequest) (resp *protocol.SocketResponse) {
+	defer func() {
+		if r := recover(); r != nil {
+			resp = failedResponse(types.NewError(types.ErrInternal, fmt.Sprintf("netplugin failed: panic: %v", r), ""))
+		}
+	}()
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	e := PluginMainFuncsWithIO(s.Funcs, s.VersionInfo, "", PluginIO{
+		// The runtime's file descriptors do not cross the socket, so log
+		// records go to the response's stderr instead
+		Getenv: protocol.GetenvWithoutLogFD(req.Env),
+		Stdin:  bytes.NewReader(req.Stdin),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	if e != nil {
+		resp = failedResponse(e)
+		resp.Stderr = stderr.Bytes()
+		return resp
+	}
+	return &protocol.SocketResponse{Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}
+}
+
+func failedResponse(e *types.Error) *protocol.SocketResponse {
+	stdout := &bytes.Buffer{}
+	if err := json.NewEncoder(stdout).Encode(e); err != nil {
+		return &protocol.SocketResponse{Failed: true, Stderr: []byte(err.Error())}
+	}
+	return &protocol.SocketResponse{Stdout: stdout.Bytes(), Failed: true}
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package skel
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/internal/protocol"
+	"github.com/containernetworking/cni/pkg/types"
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/cni/pkg/version"
+)
+
+var _ = Describe("serving plugins over a socket", func() {
+	var (
+		server     *Server
+		socketPath string
+		served     chan error
+	)
+
+	BeforeEach(func() {
+		// Socket paths are limited in length, so avoid the long test
+		// temporary directory
+		dir, err := os.MkdirTemp("", "cni-skel")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		socketPath = filepath.Join(dir, "plugin.sock")
+
+		server = &Server{
+			Funcs: CNIFuncs{
+				Add: func(args *CmdArgs) error {
+					args.Logger(nil).Debug("adding", "ifname", args.IfName)
+					return args.PrintResult(&current.Result{CNIVersion: "1.0.0"}, "1.0.0")
+				},
+				Del: func(*CmdArgs) error {
+					return types.NewError(types.ErrTryAgainLater, "busy", "")
+				},
+				Check: func(*CmdArgs) error {
+					panic("boom")
+				},
+			},
+			VersionInfo: version.PluginSupports("1.0.0"),
+		}
+		// A stale socket from an earlier daemon is replaced
+		stale, err := net.Listen("unix", socketPath)
+		Expect(err).NotTo(HaveOccurred())
+		stale.(*net.UnixListener).SetUnlinkOnClose(false)
+		stale.Close()
+
+		served = make(chan error, 1)
+		go func() {
+			served <- server.ListenAndServe(socketPath)
+		}()
+		Eventually(func() error {
+			conn, err := net.Dial("unix", socketPath)
+			if err == nil {
+				conn.Close()
+			}
+			return err
+		}).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(server.Close()).To(Succeed())
+		Eventually(served).Should(Receive(Equal(ErrServerClosed)))
+	})
+
+	request := func(command string, env ...string) *protocol.SocketResponse {
+		conn, err := net.Dial("unix", socketPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close()
+		Expect(json.NewEncoder(conn).Encode(&protocol.SocketRequest{
+			Env: append([]string{
+				"CNI_COMMAND=" + command,
+				"CNI_CONTAINERID=some-container-id",
+				"CNI_IFNAME=eth0",
+				"CNI_NETNS=/some/netns",
+				"CNI_PATH=/some/bin",
+			}, env...),
+			Stdin: []byte(`{"cniVersion": "1.0.0", "name": "net", "type": "test"}`),
+		})).To(Succeed())
+		resp := &protocol.SocketResponse{}
+		Expect(json.NewDecoder(conn).Decode(resp)).To(Succeed())
+		return resp
+	}
+
+	It("returns the plugin's output", func() {
+		resp := request("ADD")
+		Expect(resp.Failed).To(BeFalse())
+		Expect(resp.Stdout).To(MatchJSON(`{"cniVersion": "1.0.0"}`))
+
+		resp = request("VERSION")
+		Expect(resp.Failed).To(BeFalse())
+		Expect(resp.Stdout).To(MatchJSON(`{"cniVersion": "1.1.0", "supportedVersions": ["1.0.0"]}`))
+	})
+
+	It("logs at the request's level to the response's stderr", func() {
+		resp := request("ADD")
+		Expect(resp.Stderr).To(BeEmpty())
+
+		resp = request("ADD", EnvLogLevel+"=DEBUG", EnvLogFD+"=9")
+		Expect(resp.Failed).To(BeFalse())
+		Expect(string(resp.Stderr)).To(ContainSubstring(`"level":"DEBUG","msg":"adding","ifname":"eth0"}`))
+	})
+
+	It("returns errors as failures", func() {
+		resp := request("DEL")
+		Expect(resp.Failed).To(BeTrue())
+		Expect(resp.Stdout).To(MatchJSON(`{"code": 11, "msg": "busy"}`))
+
+		resp = request("CHECK")
+		Expect(resp.Failed).To(BeTrue())
+		Expect(resp.Stdout).To(MatchJSON(`{"code": 999, "msg": "netplugin failed: panic: boom"}`))
+
+		resp = request("BOGUS")
+		Expect(resp.Failed).To(BeTrue())
+		Expect(resp.Stdout).To(MatchJSON(`{"code": 4, "msg": "unknown CNI_COMMAND: BOGUS"}`))
+	})
+
+	It("rejects malformed requests", func() {
+		conn, err := net.Dial("unix", socketPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close()
+		_, err = conn.Write([]byte("not json\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		resp := &protocol.SocketResponse{}
+		Expect(json.NewDecoder(conn).Decode(resp)).To(Succeed())
+		Expect(resp.Failed).To(BeTrue())
+		Expect(string(resp.Stdout)).To(ContainSubstring("error decoding request"))
+	})
+})
@@ -42,6 +42,35 @@ type CmdArgs struct {
 	Path          string
 	NetnsOverride string
 	StdinData     []byte
+
+	// stdout is where the plugin's output goes
+	stdout io.Writer
+	// stderr, logFD and logLevel are the request's stderr, CNI_LOG_FD and
+	// CNI_LOG_LEVEL, used by Logger
+	stderr   io.Writer
+	logFD    string
+	logLevel string
+}
+
+// Stdout returns the writer the plugin's result must be written to. It is
+// os.Stdout unless the plugin runs in-process, as with
+// inprocess.Exec, in which case writing the result to os.Stdout
+// directly loses it.
+func (args *CmdArgs) Stdout() io.Writer {
+	if args.stdout == nil {
+		return os.Stdout
+	}
+	return args.stdout
+}
+
+// PrintResult converts result to version and prints it to Stdout. Plugins
+// that may run in-process should use it instead of types.PrintResult.
+func (args *CmdArgs) PrintResult(result types.Result, version string) error {
+	newResult, err := result.GetAsVersion(version)
+	if err != nil {
+		return err
+	}
+	return newResult.PrintTo(args.Stdout())
 }
 
 type dispatcher struct {
@@ -69,11 +98,12 @@ func (t *dispatcher) getCmdArgsFromEnv() (string, *CmdArgs, *types.Error) {
 			"CNI_COMMAND",
 			&cmd,
 			reqForCmdEntry{
-				"ADD":    true,
-				"CHECK":  true,
-				"DEL":    true,
-				"GC":     true,
-				"STATUS": true,
+				"ADD":      true,
+				"CHECK":    true,
+				"DEL":      true,
+				"GC":       true,
+				"STATUS":   true,
+				"VALIDATE": true,
 			},
 			nil,
 		},
@@ -121,11 +151,12 @@ func (t *dispatcher) getCmdArgsFromEnv() (string, *CmdArgs, *types.Error) {
 			"CNI_PATH",
 			&path,
 			reqForCmdEntry{
-				"ADD":    true,
-				"CHECK":  true,
-				"DEL":    true,
-				"GC":     true,
-				"STATUS": true,
+				"ADD":      true,
+				"CHECK":    true,
+				"DEL":      true,
+				"GC":       true,
+				"STATUS":   true,
+				"VALIDATE": true,
 			},
 			nil,
 		},
@@ -183,6 +214,10 @@ func (t *dispatcher) getCmdArgsFromEnv() (string, *CmdArgs, *types.Error) {
 		Path:          path,
 		StdinData:     stdinData,
 		NetnsOverride: netnsOverride,
+		stdout:        t.Stdout,
+		stderr:        t.Stderr,
+		logFD:         t.Getenv(EnvLogFD),
+		logLevel:      t.Getenv(EnvLogLevel),
 	}
 	return cmd, cmdArgs, nil
 }
@@ -192,6 +227,10 @@ func (t *dispatcher) checkVersionAndCall(cmdArgs *CmdArgs, pluginVersionInfo ver
 	if err != nil {
 		return types.NewError(types.ErrDecodingFailure, err.Error(), "")
 	}
+	if configVersion == version.DraftVersion && !version.DraftEnabled() {
+		return types.NewError(types.ErrIncompatibleCNIVersion, "incompatible CNI versions",
+			fmt.Sprintf("config is draft version %q, which is not enabled", configVersion))
+	}
 	verErr := t.VersionReconciler.Check(configVersion, pluginVersionInfo)
 	if verErr != nil {
 		return types.NewError(types.ErrIncompatibleCNIVersion, "incompatible CNI versions", verErr.Details())
@@ -334,6 +373,13 @@ func (t *dispatcher) pluginMain(funcs CNIFuncs, versionInfo version.PluginInfo,
 			}
 		}
 		return types.NewError(types.ErrIncompatibleCNIVersion, "plugin version does not allow STATUS", "")
+	case "VALIDATE":
+		// VALIDATE is experimental and not part of the specification, so
+		// plugins that do not implement it answer like plugins that predate it.
+		if funcs.Validate == nil {
+			return types.NewError(types.ErrInvalidEnvironmentVariables, fmt.Sprintf("unknown CNI_COMMAND: %v", cmd), "")
+		}
+		return t.checkVersionAndCall(cmdArgs, versionInfo, funcs.Validate)
 	case "VERSION":
 		if err := versionInfo.Encode(t.Stdout); err != nil {
 			return types.NewError(types.ErrIOFailure, err.Error(), "")
@@ -371,6 +417,12 @@ type CNIFuncs struct {
 	Check  func(_ *CmdArgs) error
 	GC     func(_ *CmdArgs) error
 	Status func(_ *CmdArgs) error
+	// Validate is called for the experimental VALIDATE command, which asks
+	// the plugin whether the configuration in StdinData is acceptable
+	// without performing any other action. Only CNI_COMMAND and CNI_PATH
+	// are set. It should return an error describing why the configuration
+	// would be rejected, or nil if it is acceptable.
+	Validate func(_ *CmdArgs) error
 }
 
 // PluginMainFuncsWithError is the core "main" for a plugin. It accepts
@@ -394,6 +446,44 @@ func PluginMainFuncsWithError(funcs CNIFuncs, versionInfo version.PluginInfo, ab
 	}).pluginMain(funcs, versionInfo, about)
 }
 
+// PluginIO is the environment and standard streams of a plugin
+type PluginIO struct {
+	// Getenv looks up environment variables; nil means an empty environment
+	Getenv func(string) string
+	// Stdin is the plugin's input; nil means no input
+	Stdin io.Reader
+	// Stdout and Stderr receive the plugin's output; nil discards it
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// PluginMainFuncsWithIO is like PluginMainFuncsWithError, but the plugin
+// reads its environment and standard streams from pio instead of the
+// process's. It allows running a plugin in-process with the same protocol
+// handling as when it is executed. The callbacks in funcs must write their
+// results to CmdArgs.Stdout.
+func PluginMainFuncsWithIO(funcs CNIFuncs, versionInfo version.PluginInfo, about string, pio PluginIO) *types.Error {
+	t := &dispatcher{
+		Getenv: pio.Getenv,
+		Stdin:  pio.Stdin,
+		Stdout: pio.Stdout,
+		Stderr: pio.Stderr,
+	}
+	if t.Getenv == nil {
+		t.Getenv = func(string) string { return "" }
+	}
+	if t.Stdin == nil {
+		t.Stdin = bytes.NewReader(nil)
+	}
+	if t.Stdout == nil {
+		t.Stdout = io.Discard
+	}
+	if t.Stderr == nil {
+		t.Stderr = io.Discard
+	}
+	return t.pluginMain(funcs, versionInfo, about)
+}
+
 // PluginMainFuncs is the core "main" for a plugin which includes automatic error handling.
 // This is a newer alternative func to PluginMain which abstracts CNI commands within a
 // CNIFuncs interface.
@@ -15,15 +15,20 @@
 package skel
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
+	"os"
+	"runtime"
+	"strconv"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/containernetworking/cni/pkg/types"
+	current "github.com/containernetworking/cni/pkg/types/100"
 	"github.com/containernetworking/cni/pkg/version"
 )
 
@@ -93,6 +98,8 @@ var _ = Describe("dispatching to the correct callback", func() {
 			Args:        "some;extra;args",
 			Path:        "/some/cni/path",
 			StdinData:   []byte(stdinData),
+			stdout:      stdout,
+			stderr:      stderr,
 		}
 	})
 
@@ -118,6 +125,8 @@ var _ = Describe("dispatching to the correct callback", func() {
 			Args:        "some;extra;args",
 			Path:        "/some/cni/path",
 			StdinData:   []byte(stdinData),
+			stdout:      stdout,
+			stderr:      stderr,
 		}
 
 		It("extracts env vars and stdin data and calls cmdAdd", func() {
@@ -416,6 +425,8 @@ var _ = Describe("dispatching to the correct callback", func() {
 			expectedCmdArgs = &CmdArgs{
 				Path:      "/some/cni/path",
 				StdinData: []byte(stdinData),
+				stdout:    stdout,
+				stderr:    stderr,
 			}
 
 			dispatch = &dispatcher{
@@ -522,6 +533,95 @@ var _ = Describe("dispatching to the correct callback", func() {
 				Expect(cmdGC.CallCount).To(Equal(0))
 			})
 		})
+
+		Context("when the config uses the draft version", func() {
+			BeforeEach(func() {
+				dispatch.Stdin = strings.NewReader(`{ "cniVersion": "` + version.DraftVersion + `", "some": "config", "name": "test" }`)
+			})
+
+			It("calls cmdGC when draft support is enabled", func() {
+				DeferCleanup(version.SetDraftEnabled(true))
+				err := dispatch.pluginMain(funcs, version.All, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cmdGC.CallCount).To(Equal(1))
+			})
+
+			It("returns a useful error when draft support is disabled", func() {
+				DeferCleanup(version.SetDraftEnabled(false))
+				versionInfo = version.PluginSupports("1.1.0", version.DraftVersion)
+				err := dispatch.pluginMain(funcs, versionInfo, "")
+				Expect(err.Code).To(Equal(types.ErrIncompatibleCNIVersion))
+				Expect(err.Details).To(ContainSubstring("not enabled"))
+				Expect(cmdGC.CallCount).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when the CNI_COMMAND is VALIDATE", func() {
+		var cmdValidate *fakeCmd
+
+		BeforeEach(func() {
+			environment["CNI_COMMAND"] = "VALIDATE"
+			delete(environment, "CNI_NETNS")
+			delete(environment, "CNI_IFNAME")
+			delete(environment, "CNI_CONTAINERID")
+			delete(environment, "CNI_ARGS")
+
+			cmdValidate = &fakeCmd{}
+			funcs.Validate = cmdValidate.Func
+
+			expectedCmdArgs = &CmdArgs{
+				Path:      "/some/cni/path",
+				StdinData: []byte(stdinData),
+				stdout:    stdout,
+				stderr:    stderr,
+			}
+		})
+
+		It("extracts env vars and stdin data and calls cmdValidate", func() {
+			err := dispatch.pluginMain(funcs, versionInfo, "")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmdAdd.CallCount).To(Equal(0))
+			Expect(cmdGC.CallCount).To(Equal(0))
+			Expect(cmdValidate.CallCount).To(Equal(1))
+			Expect(cmdValidate.Received.CmdArgs).To(Equal(expectedCmdArgs))
+		})
+
+		DescribeTable("required / optional env vars", envVarChecker,
+			Entry("command", "CNI_COMMAND", true),
+			Entry("container id", "CNI_CONTAINERID", false),
+			Entry("net ns", "CNI_NETNS", false),
+			Entry("if name", "CNI_IFNAME", false),
+			Entry("args", "CNI_ARGS", false),
+			Entry("path", "CNI_PATH", true),
+		)
+
+		It("returns the error from cmdValidate", func() {
+			cmdValidate.Returns.Error = types.NewError(types.ErrInvalidNetworkConfig, "bad range", "10.0.0.0/33")
+			err := dispatch.pluginMain(funcs, versionInfo, "")
+			Expect(err).To(Equal(&types.Error{
+				Code:    types.ErrInvalidNetworkConfig,
+				Msg:     "bad range",
+				Details: "10.0.0.0/33",
+			}))
+		})
+
+		It("checks the config version before calling cmdValidate", func() {
+			versionInfo = version.PluginSupports("0.4.0")
+			err := dispatch.pluginMain(funcs, versionInfo, "")
+			Expect(err.Code).To(Equal(types.ErrIncompatibleCNIVersion))
+			Expect(cmdValidate.CallCount).To(Equal(0))
+		})
+
+		It("reports an unknown command when the plugin does not implement it", func() {
+			funcs.Validate = nil
+			err := dispatch.pluginMain(funcs, versionInfo, "")
+			Expect(err).To(Equal(&types.Error{
+				Code: types.ErrInvalidEnvironmentVariables,
+				Msg:  "unknown CNI_COMMAND: VALIDATE",
+			}))
+		})
 	})
 
 	Context("when the CNI_COMMAND is DEL", func() {
@@ -714,6 +814,97 @@ var _ = Describe("dispatching to the correct callback", func() {
 	})
 })
 
+var _ = Describe("running a plugin with PluginMainFuncsWithIO", func() {
+	var (
+		environment map[string]string
+		pio         PluginIO
+		stdout      *bytes.Buffer
+		versionInfo version.PluginInfo
+	)
+
+	BeforeEach(func() {
+		environment = map[string]string{
+			"CNI_COMMAND":     "ADD",
+			"CNI_CONTAINERID": "some-container-id",
+			"CNI_IFNAME":      "eth0",
+			"CNI_PATH":        "/some/cni/path",
+			"CNI_NETNS":       "/some/netns/path",
+		}
+		stdout = &bytes.Buffer{}
+		pio = PluginIO{
+			Getenv: func(key string) string { return environment[key] },
+			Stdin:  strings.NewReader(`{"name": "skel-test", "cniVersion": "1.0.0"}`),
+			Stdout: stdout,
+		}
+		versionInfo = version.PluginSupports("0.4.0", "1.0.0")
+	})
+
+	It("writes results printed through CmdArgs to the provided stdout", func() {
+		funcs := CNIFuncs{
+			Add: func(args *CmdArgs) error {
+				Expect(args.Stdout()).To(BeIdenticalTo(stdout))
+				return args.PrintResult(&current.Result{CNIVersion: "1.0.0"}, "0.4.0")
+			},
+		}
+		Expect(PluginMainFuncsWithIO(funcs, versionInfo, "", pio)).To(BeNil())
+		Expect(stdout.String()).To(MatchJSON(`{"cniVersion": "0.4.0", "dns": {}}`))
+	})
+
+	It("answers VERSION", func() {
+		environment = map[string]string{"CNI_COMMAND": "VERSION"}
+		Expect(PluginMainFuncsWithIO(CNIFuncs{}, versionInfo, "", pio)).To(BeNil())
+		Expect(stdout.String()).To(MatchJSON(`{"cniVersion": "1.1.0", "supportedVersions": ["0.4.0", "1.0.0"]}`))
+	})
+
+	It("logs at the request's level to the provided stderr", func() {
+		stderr := &bytes.Buffer{}
+		pio.Stderr = stderr
+		environment[EnvLogLevel] = "DEBUG"
+		funcs := CNIFuncs{
+			Add: func(args *CmdArgs) error {
+				args.Logger(nil).Debug("adding", "ifname", args.IfName)
+				return args.PrintResult(&current.Result{CNIVersion: "1.0.0"}, "1.0.0")
+			},
+		}
+		Expect(PluginMainFuncsWithIO(funcs, versionInfo, "", pio)).To(BeNil())
+		Expect(stderr.String()).To(ContainSubstring(`"level":"DEBUG","msg":"adding","ifname":"eth0"}`))
+	})
+
+	It("opens the request's log file descriptor once", func() {
+		r, w, err := os.Pipe()
+		Expect(err).NotTo(HaveOccurred())
+		defer r.Close()
+		defer w.Close()
+		environment[EnvLogFD] = strconv.Itoa(int(w.Fd()))
+		funcs := CNIFuncs{
+			Add: func(args *CmdArgs) error {
+				args.Logger(nil).Info("first")
+				// The first logger's file must not close the descriptor
+				// when it is collected
+				runtime.GC()
+				runtime.GC()
+				args.Logger(nil).Info("second")
+				return args.PrintResult(&current.Result{CNIVersion: "1.0.0"}, "1.0.0")
+			},
+		}
+		Expect(PluginMainFuncsWithIO(funcs, versionInfo, "", pio)).To(BeNil())
+
+		lines := bufio.NewReader(r)
+		for _, msg := range []string{"first", "second"} {
+			line, err := lines.ReadString('\n')
+			Expect(err).NotTo(HaveOccurred())
+			Expect(line).To(ContainSubstring(`"msg":"` + msg + `"`))
+		}
+	})
+
+	It("validates the environment", func() {
+		pio.Getenv = nil
+		err := PluginMainFuncsWithIO(CNIFuncs{}, versionInfo, "", pio)
+		Expect(err).NotTo(BeNil())
+		Expect(err.Code).To(Equal(uint(types.ErrInvalidEnvironmentVariables)))
+	})
+})
+
 // BadReader is an io.Reader which always errors
 type BadReader struct {
 	Error     error
@@ -44,6 +44,11 @@ func init() {
 	convert.RegisterConverter("0.3.1", []string{"0.1.0", "0.2.0"}, convertTo02x)
 	convert.RegisterConverter("0.3.0", []string{"0.1.0", "0.2.0"}, convertTo02x)
 
+	// Loss reporters for down-conversions
+	for _, v := range supportedVersions {
+		convert.RegisterLossReporter(v, []string{"0.1.0", "0.2.0"}, lossesTo02x)
+	}
+
 	// Creator
 	convert.RegisterCreator(supportedVersions, NewResult)
 }
@@ -191,6 +196,48 @@ func convertTo02x(from types.Result, toVersion string) (types.Result, error) {
 	return toResult, nil
 }
 
+// lossesTo02x reports the fields convertTo02x cannot carry over: interfaces,
+// all but the first IP address of each family, interface indexes, routes for
+// a family without an address and route attributes beyond dst and gw.
+func lossesTo02x(from types.Result, _ string) []string {
+	fromResult := from.(*Result)
+	var lost []string
+
+	for i := range fromResult.Interfaces {
+		lost = append(lost, fmt.Sprintf("interfaces[%d]", i))
+	}
+
+	have4, have6 := false, false
+	for i, fromIP := range fromResult.IPs {
+		switch {
+		case fromIP.Version == "4" && !have4:
+			have4 = true
+		case fromIP.Version == "6" && !have6:
+			have6 = true
+		default:
+			lost = append(lost, fmt.Sprintf("ips[%d]", i))
+			continue
+		}
+		if fromIP.Interface != nil {
+			lost = append(lost, fmt.Sprintf("ips[%d].interface", i))
+		}
+	}
+
+	var kept []*types.Route
+	for i, fromRoute := range fromResult.Routes {
+		is4 := fromRoute.Dst.IP.To4() != nil
+		if (is4 && !have4) || (!is4 && !have6) {
+			lost = append(lost, fmt.Sprintf("routes[%d]", i))
+			kept = append(kept, nil)
+			continue
+		}
+		kept = append(kept, fromRoute)
+	}
+	lost = append(lost, convert.RouteLosses("routes", kept)...)
+
+	return lost
+}
+
 func (r *Result) Version() string {
 	return r.CNIVersion
 }
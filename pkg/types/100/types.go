@@ -48,6 +48,12 @@ func init() {
 	convert.RegisterConverter("1.1.0", []string{"0.1.0", "0.2.0"}, convertTo02x)
 	convert.RegisterConverter("1.1.0", []string{"1.0.0"}, convertFrom100)
 
+	// Loss reporters for down-conversions
+	for _, v := range supportedVersions {
+		convert.RegisterLossReporter(v, []string{"0.3.0", "0.3.1", "0.4.0"}, lossesTo04x)
+		convert.RegisterLossReporter(v, []string{"0.1.0", "0.2.0"}, lossesTo02x)
+	}
+
 	// Creator
 	convert.RegisterCreator(supportedVersions, NewResult)
 }
@@ -240,6 +246,50 @@ func convertTo02x(from types.Result, toVersion string) (types.Result, error) {
 	return result02x, nil
 }
 
+// lossesTo04x reports the interface and route attributes that were added
+// after CNI specification version 0.4.0.
+func lossesTo04x(from types.Result, _ string) []string {
+	fromResult := from.(*Result)
+	var lost []string
+	for i, intf := range fromResult.Interfaces {
+		if intf.Mtu != 0 {
+			lost = append(lost, fmt.Sprintf("interfaces[%d].mtu", i))
+		}
+		if intf.SocketPath != "" {
+			lost = append(lost, fmt.Sprintf("interfaces[%d].socketPath", i))
+		}
+		if intf.PciID != "" {
+			lost = append(lost, fmt.Sprintf("interfaces[%d].pciID", i))
+		}
+	}
+	return append(lost, convert.RouteLosses("routes", fromResult.Routes)...)
+}
+
+// lossesTo02x combines the losses of converting to 0.4.0 with the losses
+// of converting that intermediate result down to toVersion.
+func lossesTo02x(from types.Result, toVersion string) []string {
+	lost := lossesTo04x(from, "0.4.0")
+	result040, err := convertTo04x(from, "0.4.0")
+	if err != nil {
+		return lost
+	}
+	seen := make(map[string]bool, len(lost))
+	for _, field := range lost {
+		seen[field] = true
+	}
+	lost040, err := convert.Losses(result040, toVersion)
+	if err != nil {
+		// The conversion itself fails the same way and reports the error
+		return lost
+	}
+	for _, field := range lost040 {
+		if !seen[field] {
+			lost = append(lost, field)
+		}
+	}
+	return lost
+}
+
 func (r *Result) Version() string {
 	return r.CNIVersion
 }
@@ -0,0 +1,83 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package convert converts CNI Results between specification versions
+// and reports data that cannot be represented in the target version.
+package convert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containernetworking/cni/pkg/types"
+	_ "github.com/containernetworking/cni/pkg/types/020"
+	_ "github.com/containernetworking/cni/pkg/types/040"
+	_ "github.com/containernetworking/cni/pkg/types/100"
+	convert "github.com/containernetworking/cni/pkg/types/internal"
+)
+
+// LossyConversionError is returned by ConvertStrict when the Result holds
+// data that would be dropped by the conversion.
+type LossyConversionError struct {
+	FromVersion string
+	ToVersion   string
+	// Fields lists the dropped fields by their JSON path in the source
+	// Result, e.g. "interfaces[0].mtu"
+	Fields []string
+}
+
+func (e *LossyConversionError) Error() string {
+	return fmt.Sprintf("converting CNI result version %s to %s would drop %s",
+		e.FromVersion, e.ToVersion, strings.Join(e.Fields, ", "))
+}
+
+// Convert converts a CNI Result to the requested CNI specification version,
+// silently dropping any data that version cannot represent.
+func Convert(result types.Result, toVersion string) (types.Result, error) {
+	return convert.Convert(result, toVersion)
+}
+
+// ConvertWithLosses converts a CNI Result to the requested CNI specification
+// version and returns the fields that were dropped by the conversion, so that
+// the caller can decide whether the downgrade is acceptable.
+func ConvertWithLosses(result types.Result, toVersion string) (types.Result, []string, error) {
+	return convert.ConvertWithLosses(result, toVersion)
+}
+
+// ConvertChained converts a CNI Result to the requested CNI specification
+// version like ConvertWithLosses. When no converter is registered for the
+// pair of versions, it converts through the shortest chain of registered
+// converters instead, for example from an out-of-tree version to 0.4.0 by
+// way of 1.0.0. Each lost field is named by its JSON path in the Result that
+// was being converted at that step.
+func ConvertChained(result types.Result, toVersion string) (types.Result, []string, error) {
+	return convert.ConvertChained(result, toVersion)
+}
+
+// ConvertStrict converts a CNI Result to the requested CNI specification
+// version, or returns a *LossyConversionError if any data would be dropped.
+func ConvertStrict(result types.Result, toVersion string) (types.Result, error) {
+	converted, lost, err := convert.ConvertWithLosses(result, toVersion)
+	if err != nil {
+		return nil, err
+	}
+	if len(lost) > 0 {
+		return nil, &LossyConversionError{
+			FromVersion: result.Version(),
+			ToVersion:   toVersion,
+			Fields:      lost,
+		}
+	}
+	return converted, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert_test
+
+import (
+	"errors"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/pkg/types"
+	types020 "github.com/containernetworking/cni/pkg/types/020"
+	types040 "github.com/containernetworking/cni/pkg/types/040"
+	types100 "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/cni/pkg/types/convert"
+)
+
+func mustCIDR(s string) net.IPNet {
+	ipn, err := types.ParseCIDR(s)
+	Expect(err).NotTo(HaveOccurred())
+	return *ipn
+}
+
+var _ = Describe("Result conversion", func() {
+	var result *types100.Result
+
+	BeforeEach(func() {
+		result = &types100.Result{
+			CNIVersion: "1.1.0",
+			Interfaces: []*types100.Interface{
+				{
+					Name:       "eth0",
+					Mac:        "00:11:22:33:44:55",
+					Mtu:        1500,
+					SocketPath: "/path/to/vhost/fd",
+					PciID:      "8086:9a01",
+				},
+			},
+			IPs: []*types100.IPConfig{
+				{
+					Interface: types100.Int(0),
+					Address:   mustCIDR("10.1.2.3/24"),
+				},
+				{
+					Interface: types100.Int(0),
+					Address:   mustCIDR("10.1.2.4/24"),
+				},
+			},
+			Routes: []*types.Route{
+				{
+					Dst:   mustCIDR("0.0.0.0/0"),
+					MTU:   1400,
+					Table: types100.Int(100),
+				},
+				{
+					Dst: mustCIDR("::/0"),
+				},
+			},
+		}
+	})
+
+	It("reports nothing for lossless conversions", func() {
+		_, lost, err := convert.ConvertWithLosses(result, "1.0.0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lost).To(BeEmpty())
+
+		_, err = convert.ConvertStrict(result, "1.1.0")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("reports fields dropped when converting to 0.4.0", func() {
+		converted, lost, err := convert.ConvertWithLosses(result, "0.4.0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(converted).To(BeAssignableToTypeOf(&types040.Result{}))
+		Expect(lost).To(Equal([]string{
+			"interfaces[0].mtu",
+			"interfaces[0].socketPath",
+			"interfaces[0].pciID",
+			"routes[0].mtu",
+			"routes[0].table",
+		}))
+	})
+
+	It("reports fields dropped when converting to 0.2.0", func() {
+		converted, lost, err := convert.ConvertWithLosses(result, "0.2.0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(converted).To(BeAssignableToTypeOf(&types020.Result{}))
+		Expect(lost).To(Equal([]string{
+			"interfaces[0].mtu",
+			"interfaces[0].socketPath",
+			"interfaces[0].pciID",
+			"routes[0].mtu",
+			"routes[0].table",
+			"interfaces[0]",
+			"ips[0].interface",
+			"ips[1]",
+			"routes[1]",
+		}))
+	})
+
+	It("refuses lossy conversions in strict mode", func() {
+		_, err := convert.ConvertStrict(result, "0.4.0")
+		Expect(err).To(HaveOccurred())
+
+		var lossErr *convert.LossyConversionError
+		Expect(errors.As(err, &lossErr)).To(BeTrue())
+		Expect(lossErr.FromVersion).To(Equal("1.1.0"))
+		Expect(lossErr.ToVersion).To(Equal("0.4.0"))
+		Expect(lossErr.Fields).To(ContainElement("interfaces[0].mtu"))
+	})
+
+	It("reports conversion errors rather than an empty list of losses", func() {
+		_, lost, err := convert.ConvertWithLosses(result, "9.9.9")
+		Expect(err).To(MatchError("no converter for CNI result version 1.1.0 to 9.9.9"))
+		Expect(lost).To(BeNil())
+
+		_, err = convert.ConvertStrict(result, "9.9.9")
+		Expect(err).To(MatchError("no converter for CNI result version 1.1.0 to 9.9.9"))
+	})
+
+	It("allows strict conversion of results without newer fields", func() {
+		result.Interfaces[0].Mtu = 0
+		result.Interfaces[0].SocketPath = ""
+		result.Interfaces[0].PciID = ""
+		result.Routes[0].MTU = 0
+		result.Routes[0].Table = nil
+
+		converted, err := convert.ConvertStrict(result, "0.4.0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(converted.Version()).To(Equal("0.4.0"))
+	})
+})
@@ -0,0 +1,95 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	convert "github.com/containernetworking/cni/pkg/types/internal"
+)
+
+// ConvertFn converts a Result of the version it was registered for into
+// the requested toVersion. See RegisterConverter.
+type ConvertFn = convert.ConvertFn
+
+// LossFn reports the fields a ConvertFn would drop, named by their JSON
+// path in the source Result. See RegisterLossReporter.
+type LossFn = convert.LossFn
+
+// ResultFactoryFunc decodes a Result from its JSON representation.
+// See RegisterCreator.
+type ResultFactoryFunc = convert.ResultFactoryFunc
+
+// ConversionPath is a registered conversion between two Result versions.
+type ConversionPath = convert.ConversionPath
+
+// RegisterCreator registers a decoder for Results of the given versions,
+// allowing out-of-tree result versions (e.g. an experimental draft) to be
+// created by create.Create and returned by plugins. An error is returned if
+// any of the versions already has a decoder; in-tree versions can not be
+// replaced.
+func RegisterCreator(versions []string, createFn ResultFactoryFunc) error {
+	return convert.AddCreator(versions, createFn)
+}
+
+// RegisterConverter registers a converter from Results of fromVersion into
+// each of toVersions. An error is returned if a converter already exists for
+// any of the pairs.
+//
+// Out-of-tree result versions usually only need to convert to and from
+// 1.0.0. Convert and Result.GetAsVersion only use direct converters;
+// ConvertChained reaches other versions by chaining registered converters.
+func RegisterConverter(fromVersion string, toVersions []string, convertFn ConvertFn) error {
+	return convert.AddConverter(fromVersion, toVersions, convertFn)
+}
+
+// RegisterLossReporter registers a function reporting which fields are
+// dropped when converting Results of fromVersion into each of toVersions.
+// Conversions without a loss reporter are treated as lossless.
+func RegisterLossReporter(fromVersion string, toVersions []string, lossFn LossFn) error {
+	return convert.AddLossReporter(fromVersion, toVersions, lossFn)
+}
+
+// RegisterGate registers a function enabling a Result version, for
+// versions that are only supported while a feature is enabled. While it
+// returns false, the version is left out of ResultVersions and
+// ConversionPaths, and Results cannot be converted to or from it. An error
+// is returned if the version already has a gate.
+func RegisterGate(version string, enabled func() bool) error {
+	return convert.AddGate(version, enabled)
+}
+
+// ConversionPaths returns every directly registered conversion, sorted by
+// source and then target version.
+func ConversionPaths() []ConversionPath {
+	return convert.ConversionPaths()
+}
+
+// CanConvert returns true if a Result of fromVersion can be converted into
+// toVersion by Convert, that is with a directly registered converter.
+func CanConvert(fromVersion, toVersion string) bool {
+	return convert.CanConvert(fromVersion, toVersion, false)
+}
+
+// CanConvertChained returns true if a Result of fromVersion can be converted
+// into toVersion by ConvertChained, either directly or through a chain of
+// registered converters.
+func CanConvertChained(fromVersion, toVersion string) bool {
+	return convert.CanConvert(fromVersion, toVersion, true)
+}
+
+// ResultVersions returns the sorted list of Result versions that can be
+// decoded.
+func ResultVersions() []string {
+	return convert.CreatorVersions()
+}
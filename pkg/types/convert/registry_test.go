@@ -0,0 +1,185 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert_test
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/pkg/types"
+	types040 "github.com/containernetworking/cni/pkg/types/040"
+	types100 "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/cni/pkg/types/convert"
+	"github.com/containernetworking/cni/pkg/types/create"
+)
+
+const draftVersion = "1.2.0-draft"
+
+// draftResult is an out-of-tree result version that extends 1.0.0 with an
+// extra field
+type draftResult struct {
+	types100.Result
+	Extra string `json:"extra,omitempty"`
+}
+
+func (r *draftResult) Version() string { return r.CNIVersion }
+
+func (r *draftResult) GetAsVersion(version string) (types.Result, error) {
+	converted, _, err := convert.ConvertChained(r, version)
+	return converted, err
+}
+
+func (r *draftResult) Print() error { return nil }
+
+func (r *draftResult) PrintTo(w io.Writer) error {
+	return json.NewEncoder(w).Encode(r)
+}
+
+var registerDraft sync.Once
+
+func registerDraftVersion() {
+	registerDraft.Do(func() {
+		Expect(convert.RegisterCreator([]string{draftVersion}, func(data []byte) (types.Result, error) {
+			r := &draftResult{}
+			if err := json.Unmarshal(data, r); err != nil {
+				return nil, err
+			}
+			return r, nil
+		})).To(Succeed())
+
+		Expect(convert.RegisterConverter(draftVersion, []string{"1.0.0"}, func(from types.Result, toVersion string) (types.Result, error) {
+			r := from.(*draftResult).Result
+			r.CNIVersion = toVersion
+			return &r, nil
+		})).To(Succeed())
+		Expect(convert.RegisterLossReporter(draftVersion, []string{"1.0.0"}, func(from types.Result, _ string) []string {
+			if from.(*draftResult).Extra != "" {
+				return []string{"extra"}
+			}
+			return nil
+		})).To(Succeed())
+
+		Expect(convert.RegisterConverter("1.0.0", []string{draftVersion}, func(from types.Result, toVersion string) (types.Result, error) {
+			r := &draftResult{Result: *from.(*types100.Result)}
+			r.CNIVersion = toVersion
+			return r, nil
+		})).To(Succeed())
+	})
+}
+
+var _ = Describe("Registering result versions", func() {
+	BeforeEach(registerDraftVersion)
+
+	It("decodes the registered version", func() {
+		r, err := create.Create(draftVersion, []byte(`{"cniVersion":"1.2.0-draft","extra":"foo"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r).To(BeAssignableToTypeOf(&draftResult{}))
+		Expect(r.(*draftResult).Extra).To(Equal("foo"))
+
+		Expect(convert.ResultVersions()).To(ContainElements(draftVersion, "1.0.0", "0.4.0"))
+	})
+
+	It("converts to in-tree versions by chaining through 1.0.0", func() {
+		r := &draftResult{Extra: "foo"}
+		r.CNIVersion = draftVersion
+
+		Expect(convert.CanConvertChained(draftVersion, "0.4.0")).To(BeTrue())
+		converted, lost, err := convert.ConvertChained(r, "0.4.0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(converted).To(BeAssignableToTypeOf(&types040.Result{}))
+		Expect(lost).To(Equal([]string{"extra"}))
+	})
+
+	It("converts from in-tree versions by chaining through 1.0.0", func() {
+		r := &types040.Result{CNIVersion: "0.4.0"}
+		converted, lost, err := convert.ConvertChained(r, draftVersion)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(converted.Version()).To(Equal(draftVersion))
+		Expect(lost).To(BeEmpty())
+	})
+
+	It("only chains converters when asked to", func() {
+		r := &draftResult{}
+		r.CNIVersion = draftVersion
+
+		Expect(convert.CanConvert(draftVersion, "0.4.0")).To(BeFalse())
+		_, err := convert.Convert(r, "0.4.0")
+		Expect(err).To(MatchError("no converter for CNI result version 1.2.0-draft to 0.4.0"))
+		_, _, err = convert.ConvertWithLosses(r, "0.4.0")
+		Expect(err).To(HaveOccurred())
+
+		Expect(convert.CanConvert(draftVersion, "1.0.0")).To(BeTrue())
+		_, err = convert.Convert(r, "1.0.0")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("lists registered conversion paths", func() {
+		paths := convert.ConversionPaths()
+		Expect(paths).To(ContainElements(
+			convert.ConversionPath{FromVersion: draftVersion, ToVersion: "1.0.0"},
+			convert.ConversionPath{FromVersion: "1.0.0", ToVersion: draftVersion},
+			convert.ConversionPath{FromVersion: "1.1.0", ToVersion: "0.4.0"},
+		))
+		Expect(paths).NotTo(ContainElement(convert.ConversionPath{FromVersion: draftVersion, ToVersion: "0.4.0"}))
+	})
+
+	It("rejects conflicting registrations", func() {
+		noop := func(from types.Result, _ string) (types.Result, error) { return from, nil }
+		Expect(convert.RegisterConverter("1.1.0", []string{"1.0.0"}, noop)).To(MatchError("converter already registered for 1.1.0 to 1.0.0"))
+		Expect(convert.RegisterConverter(draftVersion, []string{"0.4.0", "1.0.0"}, noop)).To(MatchError("converter already registered for 1.2.0-draft to 1.0.0"))
+		Expect(convert.RegisterCreator([]string{"1.0.0"}, types100.NewResult)).To(MatchError("creator already registered for 1.0.0"))
+		Expect(convert.RegisterConverter("", []string{"1.0.0"}, noop)).To(HaveOccurred())
+		Expect(convert.RegisterConverter("9.9.9", nil, noop)).To(HaveOccurred())
+
+		// A failed registration must not leave a partial entry behind
+		Expect(convert.CanConvert(draftVersion, "0.4.0")).To(BeFalse())
+		Expect(convert.ConversionPaths()).NotTo(ContainElement(convert.ConversionPath{FromVersion: draftVersion, ToVersion: "0.4.0"}))
+	})
+
+	It("hides gated versions while they are disabled", func() {
+		const gated = "1.3.0-gated"
+		enabled := false
+		Expect(convert.RegisterGate(gated, func() bool { return enabled })).To(Succeed())
+		Expect(convert.RegisterGate(gated, func() bool { return true })).To(MatchError("gate already registered for 1.3.0-gated"))
+		Expect(convert.RegisterCreator([]string{gated}, types100.NewResult)).To(Succeed())
+		Expect(convert.RegisterConverter("1.0.0", []string{gated}, func(from types.Result, toVersion string) (types.Result, error) {
+			r := *from.(*types100.Result)
+			r.CNIVersion = toVersion
+			return &r, nil
+		})).To(Succeed())
+		path := convert.ConversionPath{FromVersion: "1.0.0", ToVersion: gated}
+
+		Expect(convert.ResultVersions()).NotTo(ContainElement(gated))
+		Expect(convert.ConversionPaths()).NotTo(ContainElement(path))
+		Expect(convert.CanConvert("1.0.0", gated)).To(BeFalse())
+		_, err := convert.Convert(&types100.Result{CNIVersion: "1.0.0"}, gated)
+		Expect(err).To(MatchError("CNI result version 1.3.0-gated is not enabled"))
+
+		enabled = true
+		Expect(convert.ResultVersions()).To(ContainElement(gated))
+		Expect(convert.ConversionPaths()).To(ContainElement(path))
+		Expect(convert.CanConvert("1.0.0", gated)).To(BeTrue())
+	})
+
+	It("reports versions that cannot be reached", func() {
+		Expect(convert.CanConvertChained("9.9.9", "1.0.0")).To(BeFalse())
+		_, err := convert.Convert(&types040.Result{CNIVersion: "0.5.0"}, "1.0.0")
+		Expect(err).To(MatchError("no converter for CNI result version 0.5.0 to 1.0.0"))
+	})
+})
@@ -16,6 +16,8 @@ package convert
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/containernetworking/cni/pkg/types"
 )
@@ -35,7 +37,78 @@ type converter struct {
 	convertFn  ConvertFn
 }
 
-var converters []*converter
+// LossFn reports the fields of the given Result that cannot be represented
+// in the CNI specification version passed in toVersion and would therefore be
+// dropped by the corresponding ConvertFn. Fields are named by their JSON path
+// in the source Result, e.g. "interfaces[0].mtu".
+type LossFn func(from types.Result, toVersion string) []string
+
+type lossReporter struct {
+	// fromVersion is the CNI Result spec version that lossFn accepts
+	fromVersion string
+	// toVersions is a list of versions that lossFn can report losses for
+	toVersions []string
+	lossFn     LossFn
+}
+
+// ConversionPath is a registered conversion between two CNI Result versions
+type ConversionPath struct {
+	FromVersion string `json:"fromVersion"`
+	ToVersion   string `json:"toVersion"`
+}
+
+var (
+	// registryLock guards converters, lossReporters, creators and gates. It
+	// is never held while calling a registered function, since those may
+	// themselves call Convert or Create.
+	registryLock  sync.RWMutex
+	converters    []*converter
+	lossReporters []*lossReporter
+	// gates hide versions from listings and conversions while their
+	// function returns false
+	gates map[string]func() bool
+)
+
+// AddGate registers a function enabling version, or returns an error if
+// the version already has one. While it returns false, the version is left
+// out of CreatorVersions and ConversionPaths and cannot be converted to or
+// from.
+func AddGate(version string, enabled func() bool) error {
+	if enabled == nil {
+		return fmt.Errorf("gate for %s must not be nil", version)
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if _, ok := gates[version]; ok {
+		return fmt.Errorf("gate already registered for %s", version)
+	}
+	if gates == nil {
+		gates = map[string]func() bool{}
+	}
+	gates[version] = enabled
+	return nil
+}
+
+// disabledVersions returns the versions whose gate returns false. It must
+// be called without registryLock held.
+func disabledVersions() map[string]bool {
+	registryLock.RLock()
+	fns := make(map[string]func() bool, len(gates))
+	for v, fn := range gates {
+		fns[v] = fn
+	}
+	registryLock.RUnlock()
+
+	disabled := map[string]bool{}
+	for v, fn := range fns {
+		if !fn() {
+			disabled[v] = true
+		}
+	}
+	return disabled
+}
 
 func findConverter(fromVersion, toVersion string) *converter {
 	for _, c := range converters {
@@ -50,9 +123,100 @@ func findConverter(fromVersion, toVersion string) *converter {
 	return nil
 }
 
-// Convert converts a CNI Result to the requested CNI specification version,
-// or returns an error if the conversion could not be performed or failed
-func Convert(from types.Result, toVersion string) (types.Result, error) {
+func findLossReporter(fromVersion, toVersion string) *lossReporter {
+	for _, r := range lossReporters {
+		if r.fromVersion == fromVersion {
+			for _, v := range r.toVersions {
+				if v == toVersion {
+					return r
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findPath returns the shortest chain of registered conversions leading from
+// fromVersion to toVersion, as the list of versions visited after fromVersion,
+// or nil if toVersion cannot be reached. Ties are broken by registration
+// order so that the result is deterministic. Disabled versions are not
+// visited.
+func findPath(fromVersion, toVersion string, disabled map[string]bool) []string {
+	prev := map[string]string{fromVersion: ""}
+	queue := []string{fromVersion}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, c := range converters {
+			if c.fromVersion != cur {
+				continue
+			}
+			for _, v := range c.toVersions {
+				if _, ok := prev[v]; ok || disabled[v] {
+					continue
+				}
+				prev[v] = cur
+				if v == toVersion {
+					var path []string
+					for step := v; step != fromVersion; step = prev[step] {
+						path = append([]string{step}, path...)
+					}
+					return path
+				}
+				queue = append(queue, v)
+			}
+		}
+	}
+	return nil
+}
+
+type step struct {
+	toVersion string
+	convertFn ConvertFn
+	lossFn    LossFn
+}
+
+// plan returns the conversion steps needed to convert a Result of fromVersion
+// into toVersion. Unless chain is set only a directly registered converter is
+// used; if it is, a direct converter is still preferred over a chain of
+// conversions.
+func plan(fromVersion, toVersion string, chain bool) ([]step, error) {
+	disabled := disabledVersions()
+	for _, v := range []string{fromVersion, toVersion} {
+		if disabled[v] {
+			return nil, fmt.Errorf("CNI result version %s is not enabled", v)
+		}
+	}
+
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	path := []string{toVersion}
+	if findConverter(fromVersion, toVersion) == nil {
+		path = nil
+		if chain {
+			path = findPath(fromVersion, toVersion, disabled)
+		}
+		if path == nil {
+			return nil, fmt.Errorf("no converter for CNI result version %s to %s",
+				fromVersion, toVersion)
+		}
+	}
+
+	steps := make([]step, 0, len(path))
+	cur := fromVersion
+	for _, v := range path {
+		s := step{toVersion: v, convertFn: findConverter(cur, v).convertFn}
+		if r := findLossReporter(cur, v); r != nil {
+			s.lossFn = r.lossFn
+		}
+		steps = append(steps, s)
+		cur = v
+	}
+	return steps, nil
+}
+
+func convert(from types.Result, toVersion string, chain, withLosses bool) (types.Result, []string, error) {
 	if toVersion == "" {
 		toVersion = "0.1.0"
 	}
@@ -61,32 +225,238 @@ func Convert(from types.Result, toVersion string) (types.Result, error) {
 
 	// Shortcut for same version
 	if fromVersion == toVersion {
-		return from, nil
+		return from, nil, nil
 	}
 
-	// Otherwise find the right converter
-	c := findConverter(fromVersion, toVersion)
-	if c == nil {
-		return nil, fmt.Errorf("no converter for CNI result version %s to %s",
-			fromVersion, toVersion)
+	// Otherwise find the right converter, or chain of converters
+	steps, err := plan(fromVersion, toVersion, chain)
+	if err != nil {
+		return nil, nil, err
 	}
-	return c.convertFn(from, toVersion)
+
+	var lost []string
+	result := from
+	for _, s := range steps {
+		if withLosses && s.lossFn != nil {
+			lost = appendUnique(lost, s.lossFn(result, s.toVersion)...)
+		}
+		result, err = s.convertFn(result, s.toVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return result, lost, nil
 }
 
-// RegisterConverter registers a CNI Result converter. SHOULD NOT BE CALLED
-// EXCEPT FROM CNI ITSELF.
-func RegisterConverter(fromVersion string, toVersions []string, convertFn ConvertFn) {
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		found := false
+		for _, existing := range list {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// Convert converts a CNI Result to the requested CNI specification version,
+// or returns an error if the conversion could not be performed or failed
+func Convert(from types.Result, toVersion string) (types.Result, error) {
+	result, _, err := convert(from, toVersion, false, false)
+	return result, err
+}
+
+// Losses returns the fields of the given Result that would be dropped when
+// converting it to the requested CNI specification version, or an error if
+// the conversion could not be performed. Conversions without a registered
+// loss reporter are assumed to be lossless.
+func Losses(from types.Result, toVersion string) ([]string, error) {
+	_, lost, err := convert(from, toVersion, false, true)
+	if err != nil {
+		return nil, err
+	}
+	return lost, nil
+}
+
+// ConvertWithLosses converts a CNI Result to the requested CNI specification
+// version and additionally returns the fields that could not be represented
+// in that version.
+func ConvertWithLosses(from types.Result, toVersion string) (types.Result, []string, error) {
+	return convert(from, toVersion, false, true)
+}
+
+// ConvertChained is like ConvertWithLosses, but when no converter is
+// registered for the pair of versions, the shortest chain of registered
+// converters is used instead. Each lost field is named by its path in the
+// Result that was being converted at that step.
+func ConvertChained(from types.Result, toVersion string) (types.Result, []string, error) {
+	return convert(from, toVersion, true, true)
+}
+
+// AddConverter registers a CNI Result converter, or returns an error if a
+// converter is already registered for any of the given version pairs.
+func AddConverter(fromVersion string, toVersions []string, convertFn ConvertFn) error {
+	if err := checkVersions(fromVersion, toVersions); err != nil {
+		return err
+	}
+	if convertFn == nil {
+		return fmt.Errorf("converter for %s must not be nil", fromVersion)
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
 	// Make sure there is no converter already registered for these
 	// from and to versions
 	for _, v := range toVersions {
 		if findConverter(fromVersion, v) != nil {
-			panic(fmt.Sprintf("converter already registered for %s to %s",
-				fromVersion, v))
+			return fmt.Errorf("converter already registered for %s to %s",
+				fromVersion, v)
 		}
 	}
 	converters = append(converters, &converter{
 		fromVersion: fromVersion,
-		toVersions:  toVersions,
+		toVersions:  append([]string(nil), toVersions...),
 		convertFn:   convertFn,
 	})
+	return nil
+}
+
+// RegisterConverter registers a CNI Result converter. SHOULD NOT BE CALLED
+// EXCEPT FROM CNI ITSELF.
+func RegisterConverter(fromVersion string, toVersions []string, convertFn ConvertFn) {
+	if err := AddConverter(fromVersion, toVersions, convertFn); err != nil {
+		panic(err.Error())
+	}
+}
+
+// AddLossReporter registers a function reporting the fields dropped by a
+// CNI Result conversion, or returns an error if one is already registered
+// for any of the given version pairs.
+func AddLossReporter(fromVersion string, toVersions []string, lossFn LossFn) error {
+	if err := checkVersions(fromVersion, toVersions); err != nil {
+		return err
+	}
+	if lossFn == nil {
+		return fmt.Errorf("loss reporter for %s must not be nil", fromVersion)
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	for _, v := range toVersions {
+		if findLossReporter(fromVersion, v) != nil {
+			return fmt.Errorf("loss reporter already registered for %s to %s",
+				fromVersion, v)
+		}
+	}
+	lossReporters = append(lossReporters, &lossReporter{
+		fromVersion: fromVersion,
+		toVersions:  append([]string(nil), toVersions...),
+		lossFn:      lossFn,
+	})
+	return nil
+}
+
+// RegisterLossReporter registers a function reporting the fields dropped by
+// a CNI Result conversion. SHOULD NOT BE CALLED EXCEPT FROM CNI ITSELF.
+func RegisterLossReporter(fromVersion string, toVersions []string, lossFn LossFn) {
+	if err := AddLossReporter(fromVersion, toVersions, lossFn); err != nil {
+		panic(err.Error())
+	}
+}
+
+func checkVersions(fromVersion string, toVersions []string) error {
+	if fromVersion == "" {
+		return fmt.Errorf("source version must not be empty")
+	}
+	if len(toVersions) == 0 {
+		return fmt.Errorf("no target versions given for %s", fromVersion)
+	}
+	for i, v := range toVersions {
+		if v == "" {
+			return fmt.Errorf("target version for %s must not be empty", fromVersion)
+		}
+		for _, other := range toVersions[:i] {
+			if other == v {
+				return fmt.Errorf("duplicate target version %s for %s", v, fromVersion)
+			}
+		}
+	}
+	return nil
+}
+
+// ConversionPaths returns every directly registered conversion, sorted by
+// source and then target version. Conversions to or from disabled
+// versions are left out.
+func ConversionPaths() []ConversionPath {
+	disabled := disabledVersions()
+
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	var paths []ConversionPath
+	for _, c := range converters {
+		if disabled[c.fromVersion] {
+			continue
+		}
+		for _, v := range c.toVersions {
+			if v == c.fromVersion || disabled[v] {
+				continue
+			}
+			paths = append(paths, ConversionPath{FromVersion: c.fromVersion, ToVersion: v})
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if paths[i].FromVersion != paths[j].FromVersion {
+			return paths[i].FromVersion < paths[j].FromVersion
+		}
+		return paths[i].ToVersion < paths[j].ToVersion
+	})
+	return paths
+}
+
+// RouteLosses returns the fields of the given routes that are not part of
+// the route object before CNI specification version 1.1.0. The prefix names
+// the JSON path of the route list, e.g. "routes".
+func RouteLosses(prefix string, routes []*types.Route) []string {
+	var lost []string
+	for i, r := range routes {
+		if r == nil {
+			continue
+		}
+		path := fmt.Sprintf("%s[%d]", prefix, i)
+		if r.MTU != 0 {
+			lost = append(lost, path+".mtu")
+		}
+		if r.AdvMSS != 0 {
+			lost = append(lost, path+".advmss")
+		}
+		if r.Priority != 0 {
+			lost = append(lost, path+".priority")
+		}
+		if r.Table != nil {
+			lost = append(lost, path+".table")
+		}
+		if r.Scope != nil {
+			lost = append(lost, path+".scope")
+		}
+	}
+	return lost
+}
+
+// CanConvert returns true if a Result of fromVersion can be converted
+// into toVersion, directly or, if chain is set, by chaining registered
+// converters.
+func CanConvert(fromVersion, toVersion string, chain bool) bool {
+	if fromVersion == toVersion {
+		return true
+	}
+	_, err := plan(fromVersion, toVersion, chain)
+	return err == nil
 }
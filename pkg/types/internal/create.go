@@ -16,6 +16,7 @@ package convert
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/containernetworking/cni/pkg/types"
 )
@@ -31,6 +32,12 @@ type creator struct {
 var creators []*creator
 
 func findCreator(version string) *creator {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return findCreatorLocked(version)
+}
+
+func findCreatorLocked(version string) *creator {
 	for _, c := range creators {
 		for _, v := range c.versions {
 			if v == version {
@@ -50,17 +57,56 @@ func Create(version string, bytes []byte) (types.Result, error) {
 	return nil, fmt.Errorf("unsupported CNI result version %q", version)
 }
 
-// RegisterCreator registers a CNI Result creator. SHOULD NOT BE CALLED
-// EXCEPT FROM CNI ITSELF.
-func RegisterCreator(versions []string, createFn ResultFactoryFunc) {
+// AddCreator registers a CNI Result creator, or returns an error if a
+// creator is already registered for any of the given versions.
+func AddCreator(versions []string, createFn ResultFactoryFunc) error {
+	if len(versions) == 0 {
+		return fmt.Errorf("no versions given for creator")
+	}
+	if createFn == nil {
+		return fmt.Errorf("creator for %v must not be nil", versions)
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
 	// Make sure there is no creator already registered for these versions
 	for _, v := range versions {
-		if findCreator(v) != nil {
-			panic(fmt.Sprintf("creator already registered for %s", v))
+		if findCreatorLocked(v) != nil {
+			return fmt.Errorf("creator already registered for %s", v)
 		}
 	}
 	creators = append(creators, &creator{
-		versions: versions,
+		versions: append([]string(nil), versions...),
 		createFn: createFn,
 	})
+	return nil
+}
+
+// RegisterCreator registers a CNI Result creator. SHOULD NOT BE CALLED
+// EXCEPT FROM CNI ITSELF.
+func RegisterCreator(versions []string, createFn ResultFactoryFunc) {
+	if err := AddCreator(versions, createFn); err != nil {
+		panic(err.Error())
+	}
+}
+
+// CreatorVersions returns the sorted list of CNI Result versions that
+// have a registered creator and are not disabled.
+func CreatorVersions() []string {
+	disabled := disabledVersions()
+
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	var versions []string
+	for _, c := range creators {
+		for _, v := range c.versions {
+			if v != "" && !disabled[v] {
+				versions = append(versions, v)
+			}
+		}
+	}
+	sort.Strings(versions)
+	return versions
 }
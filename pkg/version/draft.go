@@ -0,0 +1,195 @@
+// Copyright 2026 CNI authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package version
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/containernetworking/cni/pkg/types"
+	types100 "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/cni/pkg/types/convert"
+)
+
+// DraftVersion is the next, unreleased version of the CNI spec. Features of
+// this version may change or disappear before it is released, so it is only
+// negotiated, advertised and accepted when draft support is enabled.
+//
+// The draft does not add anything to the 1.1.0 Result yet, so draft results
+// are decoded as a types100.Result reporting DraftVersion. They can only be
+// decoded and converted while draft support is enabled.
+const DraftVersion = "1.2.0"
+
+// DraftEnvVar is the environment variable that enables draft version
+// support when set to a true value (e.g. "1" or "true"). Since plugins
+// inherit the runtime's environment, setting it in the runtime also enables
+// draft support in plugins built with this library.
+const DraftEnvVar = "CNI_EXPERIMENTAL_DRAFT_VERSION"
+
+var (
+	draftLock     sync.RWMutex
+	draftOverride *bool
+
+	registerDraft sync.Once
+)
+
+func init() {
+	// Registers draft results if the environment enables them
+	DraftEnabled()
+}
+
+// DraftEnabled reports whether support for DraftVersion is enabled, either
+// through SetDraftEnabled or the DraftEnvVar environment variable.
+func DraftEnabled() bool {
+	enabled := draftSetting()
+	if enabled {
+		// The environment may have changed since init
+		registerDraftResult()
+	}
+	return enabled
+}
+
+func draftSetting() bool {
+	draftLock.RLock()
+	defer draftLock.RUnlock()
+	if draftOverride != nil {
+		return *draftOverride
+	}
+	enabled, _ := strconv.ParseBool(os.Getenv(DraftEnvVar))
+	return enabled
+}
+
+// SetDraftEnabled enables or disables support for DraftVersion in this
+// process, taking precedence over the DraftEnvVar environment variable.
+// The returned function restores the previous setting and is intended to
+// be deferred by tests.
+func SetDraftEnabled(enabled bool) func() {
+	if enabled {
+		registerDraftResult()
+	}
+	draftLock.Lock()
+	defer draftLock.Unlock()
+	prev := draftOverride
+	draftOverride = &enabled
+	return func() {
+		draftLock.Lock()
+		defer draftLock.Unlock()
+		draftOverride = prev
+	}
+}
+
+// currentPluginInfo reports a fixed list of released versions, plus
+// DraftVersion when draft support is enabled at the time of the call.
+type currentPluginInfo struct {
+	versions []string
+}
+
+var _ PluginInfo = &currentPluginInfo{}
+
+func (p *currentPluginInfo) SupportedVersions() []string {
+	versions := append([]string{}, p.versions...)
+	if DraftEnabled() {
+		versions = append(versions, DraftVersion)
+	}
+	return versions
+}
+
+func (p *currentPluginInfo) Encode(w io.Writer) error {
+	return (&pluginInfo{
+		CNIVersion_:        Current(),
+		SupportedVersions_: p.SupportedVersions(),
+	}).Encode(w)
+}
+
+// registerDraftResult registers DraftVersion with the Result registry the
+// first time draft support is enabled. Its gate hides DraftVersion from
+// the registry's listings and conversions once it is disabled again, and
+// the registered functions refuse to work.
+func registerDraftResult() {
+	registerDraft.Do(func() {
+		errs := []error{
+			convert.RegisterGate(DraftVersion, draftSetting),
+			convert.RegisterCreator([]string{DraftVersion}, newDraftResult),
+			convert.RegisterConverter(DraftVersion, releasedVersions, convertFromDraft),
+			convert.RegisterLossReporter(DraftVersion, releasedVersions, lossesFromDraft),
+		}
+		for _, v := range releasedVersions {
+			errs = append(errs, convert.RegisterConverter(v, []string{DraftVersion}, convertToDraft))
+		}
+		for _, err := range errs {
+			if err != nil {
+				panic(err.Error())
+			}
+		}
+	})
+}
+
+func errDraftDisabled() error {
+	return fmt.Errorf("CNI version %s is a draft and draft support is not enabled", DraftVersion)
+}
+
+func newDraftResult(data []byte) (types.Result, error) {
+	if !DraftEnabled() {
+		return nil, errDraftDisabled()
+	}
+	result := &types100.Result{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, err
+	}
+	if result.CNIVersion != DraftVersion {
+		return nil, fmt.Errorf("result type supports %v but unmarshalled CNIVersion is %q",
+			[]string{DraftVersion}, result.CNIVersion)
+	}
+	return result, nil
+}
+
+// withVersion returns a copy of r reporting another version; the types
+// are the same
+func withVersion(r *types100.Result, version string) *types100.Result {
+	result := *r
+	result.CNIVersion = version
+	return &result
+}
+
+func convertFromDraft(from types.Result, toVersion string) (types.Result, error) {
+	if !DraftEnabled() {
+		return nil, errDraftDisabled()
+	}
+	return convert.Convert(withVersion(from.(*types100.Result), "1.1.0"), toVersion)
+}
+
+func convertToDraft(from types.Result, toVersion string) (types.Result, error) {
+	if !DraftEnabled() {
+		return nil, errDraftDisabled()
+	}
+	result, err := convert.Convert(from, "1.1.0")
+	if err != nil {
+		return nil, err
+	}
+	return withVersion(result.(*types100.Result), toVersion), nil
+}
+
+func lossesFromDraft(from types.Result, toVersion string) []string {
+	_, lost, err := convert.ConvertWithLosses(withVersion(from.(*types100.Result), "1.1.0"), toVersion)
+	if err != nil {
+		// convertFromDraft fails the same way and reports the error
+		return nil
+	}
+	return lost
+}
@@ -22,8 +22,12 @@ import (
 	"github.com/containernetworking/cni/pkg/types/create"
 )
 
-// Current reports the version of the CNI spec implemented by this library
+// Current reports the version of the CNI spec implemented by this library,
+// which is DraftVersion when draft support is enabled
 func Current() string {
+	if DraftEnabled() {
+		return DraftVersion
+	}
 	return "1.1.0"
 }
 
@@ -34,11 +38,15 @@ func Current() string {
 //
 // Any future CNI spec versions which meet this definition should be added to
 // this list.
+//
+// All additionally reports DraftVersion while draft support is enabled.
 var (
-	Legacy = PluginSupports("0.1.0", "0.2.0")
-	All    = PluginSupports("0.1.0", "0.2.0", "0.3.0", "0.3.1", "0.4.0", "1.0.0", "1.1.0")
+	Legacy            = PluginSupports("0.1.0", "0.2.0")
+	All    PluginInfo = &currentPluginInfo{versions: releasedVersions}
 )
 
+var releasedVersions = []string{"0.1.0", "0.2.0", "0.3.0", "0.3.1", "0.4.0", "1.0.0", "1.1.0"}
+
 // VersionsFrom returns a list of versions starting from minVer, inclusive
 func VersionsStartingFrom(minVer string) PluginInfo {
 	out := []string{}
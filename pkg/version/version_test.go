@@ -24,6 +24,7 @@ import (
 
 	"github.com/containernetworking/cni/pkg/types"
 	cniv1 "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/cni/pkg/types/convert"
 	"github.com/containernetworking/cni/pkg/version"
 )
 
@@ -33,6 +34,66 @@ var _ = Describe("Version operations", func() {
 		Expect(actual.SupportedVersions()).To(Equal([]string{"0.3.1", "0.4.0", "1.0.0", "1.1.0"}))
 	})
 
+	Context("when draft support is enabled", func() {
+		It("reports the draft version as current and supported", func() {
+			restore := version.SetDraftEnabled(true)
+			Expect(version.DraftEnabled()).To(BeTrue())
+			Expect(version.Current()).To(Equal(version.DraftVersion))
+			Expect(version.All.SupportedVersions()).To(ContainElement(version.DraftVersion))
+
+			restore()
+			Expect(version.Current()).To(Equal("1.1.0"))
+			Expect(version.All.SupportedVersions()).NotTo(ContainElement(version.DraftVersion))
+		})
+
+		It("decodes and converts draft results", func() {
+			DeferCleanup(version.SetDraftEnabled(true))
+			Expect(convert.ResultVersions()).To(ContainElement(version.DraftVersion))
+			res, err := version.NewResult(version.DraftVersion, []byte(`{"cniVersion": "1.2.0", "interfaces": [{"name": "eth0", "mtu": 1500}]}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(BeAssignableToTypeOf(&cniv1.Result{}))
+			Expect(res.Version()).To(Equal(version.DraftVersion))
+
+			res040, err := res.GetAsVersion("0.4.0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res040.Version()).To(Equal("0.4.0"))
+			_, lost, err := convert.ConvertWithLosses(res, "0.4.0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lost).To(Equal([]string{"interfaces[0].mtu"}))
+
+			back, err := res040.GetAsVersion(version.DraftVersion)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(back.Version()).To(Equal(version.DraftVersion))
+			Expect(back.(*cniv1.Result).Interfaces[0].Name).To(Equal("eth0"))
+		})
+
+		It("can be enabled through the environment", func() {
+			GinkgoT().Setenv(version.DraftEnvVar, "true")
+			Expect(version.DraftEnabled()).To(BeTrue())
+
+			// An explicit setting takes precedence over the environment
+			restore := version.SetDraftEnabled(false)
+			Expect(version.DraftEnabled()).To(BeFalse())
+			restore()
+			Expect(version.DraftEnabled()).To(BeTrue())
+		})
+	})
+
+	It("refuses draft results unless draft support is enabled", func() {
+		DeferCleanup(version.SetDraftEnabled(false))
+		_, err := version.NewResult(version.DraftVersion, []byte(`{"cniVersion": "1.2.0"}`))
+		Expect(err).To(HaveOccurred())
+
+		_, err = (&cniv1.Result{CNIVersion: "1.1.0"}).GetAsVersion(version.DraftVersion)
+		Expect(err).To(HaveOccurred())
+
+		// Even after draft results were registered by enabling support
+		version.SetDraftEnabled(true)()
+		Expect(convert.ResultVersions()).NotTo(ContainElement(version.DraftVersion))
+		Expect(convert.CanConvert("1.1.0", version.DraftVersion)).To(BeFalse())
+		Expect(convert.CanConvert(version.DraftVersion, "1.1.0")).To(BeFalse())
+	})
+
 	Context("when a prevResult is available", func() {
 		It("parses the prevResult", func() {
 			rawBytes := []byte(`{
@@ -31,6 +31,7 @@ type Debug struct {
 	ReportError          string
 	ReportErrorCode      uint
 	ReportStderr         string
+	ReportLog            string
 	ReportVersionSupport []string
 	ExitWithCode         int
 
@@ -25,6 +25,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"strings"
 
@@ -133,6 +134,11 @@ func debugBehavior(args *skel.CmdArgs, command string) error {
 			return err
 		}
 	}
+	if debug.ReportLog != "" {
+		logger := args.Logger(nil)
+		logger.Debug(debug.ReportLog)
+		logger.Info(debug.ReportLog, "attempt", 1, slog.Group("iface", "name", args.IfName))
+	}
 	switch {
 	case debug.ReportError != "":
 		ec := debug.ReportErrorCode
@@ -222,6 +228,10 @@ func cmdStatus(args *skel.CmdArgs) error {
 	return debugBehavior(args, "STATUS")
 }
 
+func cmdValidate(args *skel.CmdArgs) error {
+	return debugBehavior(args, "VALIDATE")
+}
+
 func saveStdin() ([]byte, error) {
 	// Read original stdin
 	stdinData, err := io.ReadAll(os.Stdin)
@@ -254,10 +264,11 @@ func main() {
 
 	supportedVersions := debugGetSupportedVersions(stdinData)
 	skel.PluginMainFuncs(skel.CNIFuncs{
-		Add:    cmdAdd,
-		Check:  cmdCheck,
-		Del:    cmdDel,
-		GC:     cmdGC,
-		Status: cmdStatus,
+		Add:      cmdAdd,
+		Check:    cmdCheck,
+		Del:      cmdDel,
+		GC:       cmdGC,
+		Status:   cmdStatus,
+		Validate: cmdValidate,
 	}, version.PluginSupports(supportedVersions...), "CNI noop plugin v0.7.0")
 }